@@ -34,6 +34,7 @@ func main() {
 			// Store logger and tracker in the context
 			cCtx.Context = common.WithLogger(cCtx.Context, logger)
 			cCtx.Context = common.WithProgressTracker(cCtx.Context, tracker)
+			cCtx.Context = common.WithVerbose(cCtx.Context, cCtx.Bool("verbose"))
 
 			// Handle first-run telemetry prompt (only for non-telemetry commands)
 			if cCtx.Command.Name != "telemetry" && cCtx.Command.Name != "help" && cCtx.Command.Name != "version" {
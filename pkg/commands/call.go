@@ -51,6 +51,15 @@ var CallCommand = &cli.Command{
 			)
 		}
 
+		// Reach the devnet on the ports recorded when it was started
+		state, err := devnet.LoadState(contextName)
+		if err != nil {
+			return err
+		}
+		if contextJSON, err = state.ApplyRPCURLsToRawContext(contextJSON); err != nil {
+			return err
+		}
+
 		// Print task if verbose
 		logger.Debug("Testing AVS tasks...")
 
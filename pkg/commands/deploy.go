@@ -32,6 +32,7 @@ var DeployCommand = &cli.Command{
 							Usage: "Use Zeus CLI to fetch l1(*) and l2(*) core addresses",
 							Value: true,
 						},
+						dryRunFlag,
 					}, common.GlobalFlags...),
 					Action: StartDeployL1Action,
 				},
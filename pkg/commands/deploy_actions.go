@@ -53,6 +53,7 @@ func StartDeployL1Action(cCtx *cli.Context) error {
 	// Extract vars
 	contextName := cCtx.String("context")
 	useZeus := cCtx.Bool("use-zeus")
+	dryRun := cCtx.Bool("dry-run")
 
 	// Migrate config
 	configsMigratedCount, err := configs.MigrateConfig(logger)
@@ -111,9 +112,11 @@ func StartDeployL1Action(cCtx *cli.Context) error {
 		} else {
 			logger.Info("Successfully updated context with addresses from Zeus")
 
-			// Write yaml back to project directory
-			if err := common.WriteYAML(yamlPath, rootNode); err != nil {
-				return fmt.Errorf("failed to save updated context: %v", err)
+			// Write yaml back to project directory, a dry run keeps the addresses in memory
+			if !dryRun {
+				if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+					return fmt.Errorf("failed to save updated context: %v", err)
+				}
 			}
 		}
 	}
@@ -152,57 +155,39 @@ func StartDeployL1Action(cCtx *cli.Context) error {
 		}
 	}
 
-	// Write yaml back to project directory
-	if err := common.WriteYAML(yamlPath, rootNode); err != nil {
-		return err
-	}
-
-	// Deploy the contracts after starting devnet unless skipped
-	if err := DeployL1ContractsAction(cCtx); err != nil {
-		return fmt.Errorf("deploy-contracts failed: %w", err)
+	// Plan the setup against the contracts already recorded in the context, as deploy scripts can't be simulated.
+	// The updated context is only read from memory, so a dry run leaves the yaml untouched
+	var plan *common.TxPlan
+	if dryRun {
+		if cCtx.Context, err = common.WithContextOverride(cCtx.Context, contextName, rootNode); err != nil {
+			return err
+		}
+		plan = startDryRun(cCtx, logger)
+		logger.Info("[dry-run] Skipping the deployL1Contracts script, the plan uses the contracts recorded in the context")
+	} else {
+		// Write yaml back to project directory
+		if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+			return err
+		}
+		if err := runSetupPipeline(cCtx, logger, []SetupStep{deployL1ContractsStep()}, nil); err != nil {
+			return err
+		}
 	}
 
-	// Sleep for 1 second to make sure new context values have been written
-	time.Sleep(1 * time.Second)
-
 	// Register AVS with EigenLayer
 	logger.Title("Registering AVS with EigenLayer...")
 	if !cCtx.Bool("skip-setup") {
-		if err := UpdateAVSMetadataAction(cCtx, logger); err != nil {
-			return fmt.Errorf("updating AVS metadata failed: %w", err)
-		}
-
-		if err := SetAVSRegistrarAction(cCtx, logger); err != nil {
-			return fmt.Errorf("setting AVS registrar failed: %w", err)
-		}
-
-		if err := CreateAVSOperatorSetsAction(cCtx, logger); err != nil {
-			return fmt.Errorf("creating AVS operator sets failed: %w", err)
-		}
-
-		if err := ConfigureOpSetCurveTypeAction(cCtx, logger); err != nil {
-			return fmt.Errorf("failed to configure OpSet in KeyRegistrar: %w", err)
-		}
-
-		if err := CreateGenerationReservationAction(cCtx, logger); err != nil {
-			return fmt.Errorf("failed to request op set generation reservation: %w", err)
-		}
-
-		if err := RegisterOperatorsToEigenLayerFromConfigAction(cCtx, logger); err != nil {
-			return fmt.Errorf("registering operators failed: %w", err)
-		}
-
-		if err := RegisterKeyInKeyRegistrarAction(cCtx, logger); err != nil {
-			return fmt.Errorf("registering key in key registrar failed: %w", err)
-		}
-
-		if err := RegisterOperatorsToAvsFromConfigAction(cCtx, logger); err != nil {
-			return fmt.Errorf("registering operators to AVS failed: %w", err)
+		if err := runSetupPipeline(cCtx, logger, avsSetupSteps(false), nil); err != nil {
+			return err
 		}
 	} else {
 		logger.Info("Skipping AVS setup steps...")
 	}
 
+	if plan != nil {
+		return printDryRunReport(cCtx, logger, contextName, plan)
+	}
+
 	// L1 Deployment complete
 	logger.Info("\n%s L1 Deployment complete\n", caser.String(contextName))
 
@@ -560,11 +545,7 @@ func UpdateAVSMetadataAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
@@ -601,6 +582,7 @@ func UpdateAVSMetadataAction(cCtx *cli.Context, logger iface.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 
 	avsAddr := ethcommon.HexToAddress(envCtx.Avs.Address)
 	return contractCaller.UpdateAVSMetadata(cCtx.Context, avsAddr, uri)
@@ -613,11 +595,7 @@ func SetAVSRegistrarAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
@@ -654,6 +632,7 @@ func SetAVSRegistrarAction(cCtx *cli.Context, logger iface.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 
 	avsAddr := ethcommon.HexToAddress(envCtx.Avs.Address)
 	var registrarAddr ethcommon.Address
@@ -681,11 +660,7 @@ func CreateAVSOperatorSetsAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations: %w", err)
 	}
@@ -722,22 +697,30 @@ func CreateAVSOperatorSetsAction(cCtx *cli.Context, logger iface.Logger) error {
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 
 	avsAddr := ethcommon.HexToAddress(envCtx.Avs.Address)
 	if len(envCtx.OperatorSets) == 0 {
 		logger.Info("No operator sets to create.")
 		return nil
 	}
-	createSetParams := make([]allocationmanager.IAllocationManagerTypesCreateSetParams, len(envCtx.OperatorSets))
-	for i, opSet := range envCtx.OperatorSets {
+	createSetParams := make([]allocationmanager.IAllocationManagerTypesCreateSetParams, 0, len(envCtx.OperatorSets))
+	for _, opSet := range envCtx.OperatorSets {
+		if !isOperatorSetSelected(cCtx, uint32(opSet.OperatorSetID)) {
+			continue
+		}
 		strategies := make([]ethcommon.Address, len(opSet.Strategies))
 		for j, strategy := range opSet.Strategies {
 			strategies[j] = ethcommon.HexToAddress(strategy.StrategyAddress)
 		}
-		createSetParams[i] = allocationmanager.IAllocationManagerTypesCreateSetParams{
+		createSetParams = append(createSetParams, allocationmanager.IAllocationManagerTypesCreateSetParams{
 			OperatorSetId: uint32(opSet.OperatorSetID),
 			Strategies:    strategies,
-		}
+		})
+	}
+	if len(createSetParams) == 0 {
+		logger.Info("No operator sets match the provided filter.")
+		return nil
 	}
 
 	logger.Info("creating operatorSets")
@@ -752,11 +735,7 @@ func RegisterOperatorsToEigenLayerFromConfigAction(cCtx *cli.Context, logger ifa
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for operator registration: %w", err)
 	}
@@ -773,12 +752,50 @@ func RegisterOperatorsToEigenLayerFromConfigAction(cCtx *cli.Context, logger ifa
 		return nil
 	}
 
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	// Each operator signs its own registration, so the registrations are sent together and mined in parallel
+	txManager := newContextTxManager(client, envCtx, logger)
+	var reqs []common.TxRequest
+	var operators []string
+	registered := map[string]bool{}
 	for _, opReg := range envCtx.OperatorRegistrations {
+		if !isOperatorSelected(cCtx, opReg.Address) || registered[strings.ToLower(opReg.Address)] {
+			continue
+		}
+		registered[strings.ToLower(opReg.Address)] = true
+
 		logger.Info("Processing registration for operator at address %s", opReg.Address)
-		if err := registerOperatorEL(cCtx, opReg.Address, logger); err != nil {
+		req, err := registerOperatorELTx(cfg, contextName, client, txManager, opReg.Address, logger)
+		if err != nil {
 			logger.Error("Failed to register operator %s with EigenLayer: %v. Continuing...", opReg.Address, err)
 			continue
 		}
+		if req != nil {
+			reqs = append(reqs, *req)
+			operators = append(operators, opReg.Address)
+		}
+	}
+
+	results := txManager.SendAll(cCtx.Context, reqs)
+	for i, result := range results {
+		if result.Err != nil {
+			logger.Error("Failed to register operator %s with EigenLayer: %v. Continuing...", operators[i], result.Err)
+			continue
+		}
+		// Dry runs only plan the registration
+		if result.Receipt == nil {
+			continue
+		}
+		logger.Info("Registered operator %s with EigenLayer (tx: %s)", operators[i], result.Tx.Hash().Hex())
 	}
 	logger.Info("Operators registration with EigenLayer completed.")
 	return nil
@@ -791,11 +808,7 @@ func RegisterOperatorsToAvsFromConfigAction(cCtx *cli.Context, logger iface.Logg
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for operator registration: %w", err)
 	}
@@ -812,13 +825,47 @@ func RegisterOperatorsToAvsFromConfigAction(cCtx *cli.Context, logger iface.Logg
 		return nil
 	}
 
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	// Each operator signs its own registrations, so they are sent together and mined in parallel
+	txManager := newContextTxManager(client, envCtx, logger)
+	var reqs []common.TxRequest
+	var registrations []common.OperatorRegistration
 	for _, opReg := range envCtx.OperatorRegistrations {
+		if !isOperatorSelected(cCtx, opReg.Address) || !isOperatorSetSelected(cCtx, uint32(opReg.OperatorSetID)) {
+			continue
+		}
 		logger.Info("Processing avs registration for operator at address %s", opReg.Address)
-		if err := registerOperatorAVS(cCtx, logger, opReg.Address, uint32(opReg.OperatorSetID), opReg.Payload); err != nil {
+		req, err := registerOperatorAVSTx(cCtx.Context, cfg, contextName, client, txManager, opReg.Address, uint32(opReg.OperatorSetID), opReg.Payload, logger)
+		if err != nil {
 			logger.Error("Failed to register operator %s for AVS: %v. Continuing...", opReg.Address, err)
 			continue
 		}
-		logger.Info("Successfully registered operator %s for OperatorSetID %d", opReg.Address, opReg.OperatorSetID)
+		if req != nil {
+			reqs = append(reqs, *req)
+			registrations = append(registrations, opReg)
+		}
+	}
+
+	results := txManager.SendAll(cCtx.Context, reqs)
+	for i, result := range results {
+		if result.Err != nil {
+			logger.Error("Failed to register operator %s for AVS: %v. Continuing...", registrations[i].Address, result.Err)
+			continue
+		}
+		// Dry runs only plan the registration
+		if result.Receipt == nil {
+			continue
+		}
+		logger.Info("Successfully registered operator %s for OperatorSetID %d", registrations[i].Address, registrations[i].OperatorSetID)
 	}
 	return nil
 }
@@ -939,11 +986,7 @@ func ConfigureOpSetCurveTypeAction(cCtx *cli.Context, logger iface.Logger) error
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for configure op set curve type: %w", err)
 	}
@@ -985,8 +1028,13 @@ func ConfigureOpSetCurveTypeAction(cCtx *cli.Context, logger iface.Logger) error
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 	// For each created operator set, configure the curve type
 	for _, opSet := range envCtx.OperatorSets {
+		if !isOperatorSetSelected(cCtx, uint32(opSet.OperatorSetID)) {
+			continue
+		}
+
 		// Determine the curve type constant
 		var curveTypeValue uint8
 		switch opSet.CurveType {
@@ -1027,11 +1075,7 @@ func CreateGenerationReservationAction(cCtx *cli.Context, logger iface.Logger) e
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for request op set generation reservation: %w", err)
 	}
@@ -1073,12 +1117,19 @@ func CreateGenerationReservationAction(cCtx *cli.Context, logger iface.Logger) e
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 
-	// Wait 1 block
-	time.Sleep(12 * time.Second)
+	// Wait 1 block, a dry run sends nothing to wait for
+	if common.TxPlanFromContext(cCtx.Context) == nil {
+		time.Sleep(12 * time.Second)
+	}
 
 	// Create reservations for each opset
 	for _, opSet := range envCtx.OperatorSets {
+		if !isOperatorSetSelected(cCtx, uint32(opSet.OperatorSetID)) {
+			continue
+		}
+
 		// Select appropriate table calculator address
 		var tableCalculatorAddr string
 		if opSet.CurveType == common.BN254Curve {
@@ -1105,11 +1156,7 @@ func RegisterKeyInKeyRegistrarAction(cCtx *cli.Context, logger iface.Logger) err
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for register key in key registrar: %w", err)
 	}
@@ -1132,8 +1179,12 @@ func RegisterKeyInKeyRegistrarAction(cCtx *cli.Context, logger iface.Logger) err
 
 	avsAddress := ethcommon.HexToAddress(envCtx.Avs.Address)
 	_, _, _, keyRegistrarAddr, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
+	txManager := newContextTxManager(client, envCtx, logger)
 
 	for _, op := range envCtx.OperatorRegistrations {
+		if !isOperatorSelected(cCtx, op.Address) || !isOperatorSetSelected(cCtx, uint32(op.OperatorSetID)) {
+			continue
+		}
 
 		for _, operator := range envCtx.Operators {
 
@@ -1159,6 +1210,7 @@ func RegisterKeyInKeyRegistrarAction(cCtx *cli.Context, logger iface.Logger) err
 				if err != nil {
 					return fmt.Errorf("failed to create contract caller: %w", err)
 				}
+				contractCaller.UseTxManager(txManager)
 
 				var blskeystorePath, blskeystorePassword string
 				for _, ks := range operator.Keystores {
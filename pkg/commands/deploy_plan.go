@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// dryRunFlag makes deploy commands simulate their transactions instead of sending them
+var dryRunFlag = &cli.BoolFlag{
+	Name:  "dry-run",
+	Usage: "Simulate the on-chain setup and print the planned transactions and how the chain differs from the context, without sending anything",
+}
+
+// startDryRun puts a plan in the command's context, so the transactions of subsequent actions are recorded in it
+func startDryRun(cCtx *cli.Context, logger iface.Logger) *common.TxPlan {
+	logger.Title("Dry run: transactions are simulated, nothing is sent")
+	plan := common.NewTxPlan()
+	cCtx.Context = common.WithTxPlan(cCtx.Context, plan)
+	return plan
+}
+
+// printDryRunReport prints the planned transactions and compares the context's setup with the state on L1
+func printDryRunReport(cCtx *cli.Context, logger iface.Logger, contextName string, plan *common.TxPlan) error {
+	txs := plan.Transactions()
+	logger.Title("Planned transactions (%d)", len(txs))
+	failed := 0
+	for i, tx := range txs {
+		if tx.Err != nil {
+			failed++
+		}
+		logger.Info("%d. %s", i+1, tx)
+	}
+	if failed > 0 {
+		logger.Warn("%d of %d simulations failed. Transactions depending on state created by earlier planned transactions fail until those are sent.", failed, len(txs))
+	}
+
+	diffs, err := diffContextState(cCtx, logger, contextName)
+	if err != nil {
+		return fmt.Errorf("failed to compare context with on-chain state: %w", err)
+	}
+	logger.Title("Context vs on-chain state")
+	outOfSync := 0
+	for _, diff := range diffs {
+		if diff.InSync() {
+			logger.Info("✅ %s", diff)
+		} else {
+			outOfSync++
+			logger.Info("❌ %s", diff)
+		}
+	}
+	logger.Info("%d of %d items differ from the context", outOfSync, len(diffs))
+	return nil
+}
+
+// diffContextState compares the operator sets, registrations and allocations in the context with the L1 contracts
+func diffContextState(cCtx *cli.Context, logger iface.Logger, contextName string) ([]common.StateDiff, error) {
+	cfg, contextName, err := common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return nil, fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return nil, fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to L1 RPC at %s: %w", l1Cfg.RPCURL, err)
+	}
+	defer client.Close()
+
+	allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
+	contractCaller, err := common.NewContractCaller(
+		envCtx.Avs.AVSPrivateKey,
+		big.NewInt(int64(l1Cfg.ChainID)),
+		client,
+		ethcommon.HexToAddress(allocationManagerAddr),
+		ethcommon.HexToAddress(delegationManagerAddr),
+		ethcommon.HexToAddress(strategyManagerAddr),
+		ethcommon.HexToAddress(""),
+		ethcommon.HexToAddress(""),
+		ethcommon.HexToAddress(""),
+		ethcommon.HexToAddress(""),
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create contract caller: %w", err)
+	}
+	return contractCaller.DiffContextState(cCtx.Context, envCtx)
+}
@@ -5,11 +5,17 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// autoPortsFlag lets several devnets run side by side by allocating free host ports instead of the fixed defaults
+var autoPortsFlag = &cli.BoolFlag{
+	Name:  "auto-ports",
+	Usage: "Allocate free host ports for every chain instead of --l1-port/--l2-port, recording them in .devkit/devnet for the other commands",
+}
+
 // DevnetCommand defines the "devnet" command
 var DevnetCommand = &cli.Command{
 	Name:  "devnet",
 	Usage: "Manage local AVS development network (Docker-based)",
-	Subcommands: []*cli.Command{
+	Subcommands: append([]*cli.Command{
 		{
 			Name:  "start",
 			Usage: "Starts Docker containers and deploys local contracts",
@@ -24,12 +30,24 @@ var DevnetCommand = &cli.Command{
 				},
 				&cli.StringFlag{
 					Name:  "fork",
-					Usage: "Fork from a specific chain (e.g. Base, OP)",
+					Usage: "Fork a chain preset (sepolia, holesky, base-sepolia, op-sepolia, mainnet, base, optimism), an L2 also forks the L1 it settles on",
+				},
+				&cli.StringFlag{
+					Name:  "fork-block",
+					Usage: "Pin the fork block in context: latest or finalized resolve on every forked chain, a block number pins the L1",
+				},
+				&cli.BoolFlag{
+					Name:  "skip-fork-check",
+					Usage: "Skip checking that the fork providers serve state at the fork block and that the EigenLayer contracts exist there",
 				},
 				&cli.BoolFlag{
 					Name:  "headless",
 					Usage: "Run without showing logs or interactive TUI",
 				},
+				&cli.BoolFlag{
+					Name:  "save-logs",
+					Usage: "Write the logs of every devnet and AVS component container into .devkit/logs/<timestamp>/",
+				},
 				&cli.IntFlag{
 					Name:  "l1-port",
 					Usage: "Specify a custom port for local devnet L1",
@@ -40,6 +58,7 @@ var DevnetCommand = &cli.Command{
 					Usage: "Specify a custom port for local devnet L2",
 					Value: 9545,
 				},
+				autoPortsFlag,
 				&cli.BoolFlag{
 					Name:  "skip-avs-run",
 					Usage: "Skip starting offchain AVS components",
@@ -70,6 +89,28 @@ var DevnetCommand = &cli.Command{
 					Usage: "Persist devnet containers unless stop is used explicitly",
 					Value: false,
 				},
+				&cli.BoolFlag{
+					Name:  "fork-cache",
+					Usage: "Serve the forks through local caching proxies so repeated starts at the same fork block are answered from .devkit/rpc-cache",
+					Value: false,
+				},
+				&cli.BoolFlag{
+					Name:  "no-fork",
+					Usage: "Start plain anvil chains and deploy the EigenLayer core contracts locally instead of forking",
+					Value: false,
+				},
+				&cli.BoolFlag{
+					Name:  "resume",
+					Usage: "Resume setup against the running devnet containers from the first incomplete step",
+					Value: false,
+				},
+				&cli.BoolFlag{
+					Name:  "keep-on-failure",
+					Usage: "Leave the devnet containers running when a setup step fails, so setup can continue with --resume",
+					Value: false,
+				},
+				readyTimeoutFlag,
+				readyBackoffFlag,
 			}, common.GlobalFlags...),
 			Action: StartDevnetAction,
 		},
@@ -94,8 +135,7 @@ var DevnetCommand = &cli.Command{
 				},
 				&cli.BoolFlag{
 					Name:  "all",
-					Usage: "Stop all running devnet containers",
-					Value: true,
+					Usage: "Stop all running devnet containers, not only the current project's",
 				},
 				&cli.StringFlag{
 					Name:  "project.name",
@@ -113,14 +153,75 @@ var DevnetCommand = &cli.Command{
 			Action: StopDevnetAction,
 		},
 		{
-			Name:   "list",
-			Usage:  "Lists all running devkit devnet containers with their ports",
+			Name:  "list",
+			Usage: "Lists all running devkit devnet containers with their ports",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "Output format: table, json or yaml",
+					Value:   "table",
+				},
+			},
 			Action: ListDevnetContainersAction,
 		},
-		// TODO: Surface the following actions as separate commands:
-		// - update-avs-metadata: Updates the AVS metadata URI on the devnet
-		// - set-avs-registrar: Sets the AVS registrar address on the devnet
-		// - create-avs-operator-sets: Creates AVS operator sets on the devnet
-		// - register-operators-from-config: Registers operators defined in config to Eigenlayer and the AVS on the devnet
-	},
+		{
+			Name:  "status",
+			Usage: "Shows chain health, block heights and what has been deployed to the devnet",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+				},
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "Print the status as JSON",
+				},
+			},
+			Action: StatusDevnetAction,
+		},
+		timeCommand,
+		logsCommand,
+		{
+			Name:      "snapshot",
+			Usage:     "Capture the running devnet's L1/L2 state and context as a named snapshot",
+			ArgsUsage: "<name>",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "Overwrite an existing snapshot with the same name",
+				},
+			}, common.GlobalFlags...),
+			Action: SnapshotDevnetAction,
+		},
+		{
+			Name:      "restore",
+			Usage:     "Start the devnet from a named snapshot instead of re-running setup",
+			ArgsUsage: "<name>",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+				},
+				&cli.IntFlag{
+					Name:  "l1-port",
+					Usage: "Specify a custom port for local L1 devnet",
+					Value: 8545,
+				},
+				&cli.IntFlag{
+					Name:  "l2-port",
+					Usage: "Specify a custom port for local L2 devnet",
+					Value: 9545,
+				},
+				autoPortsFlag,
+				readyTimeoutFlag,
+				readyBackoffFlag,
+			}, common.GlobalFlags...),
+			Action: RestoreDevnetAction,
+		},
+	}, setupStepCommands()...),
 }
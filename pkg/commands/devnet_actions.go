@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -13,10 +15,12 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"github.com/Layr-Labs/devkit-cli/config/configs"
@@ -28,7 +32,9 @@ import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
 )
 
 func StartDevnetAction(cCtx *cli.Context) error {
@@ -51,6 +57,13 @@ func StartDevnetAction(cCtx *cli.Context) error {
 	skipTransporter := cCtx.Bool("skip-transporter")
 	useZeus := cCtx.Bool("use-zeus")
 	persist := cCtx.Bool("persist")
+	resume := cCtx.Bool("resume")
+	noFork := cCtx.Bool("no-fork")
+	autoPorts := cCtx.Bool("auto-ports")
+
+	if autoPorts && (cCtx.IsSet("l1-port") || cCtx.IsSet("l2-port")) {
+		return fmt.Errorf("--auto-ports cannot be combined with --l1-port or --l2-port")
+	}
 
 	// Migrate config
 	configsMigratedCount, err := configs.MigrateConfig(logger)
@@ -92,6 +105,65 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		return fmt.Errorf("loading context nodes failed: %w", err)
 	}
 
+	// Point the chains at the selected preset, replacing the fork blocks and EigenLayer addresses in context
+	if fork := cCtx.String("fork"); fork != "" {
+		l1Preset, l2Preset, err := common.ResolveForkPresets(fork)
+		if err != nil {
+			return err
+		}
+		if err := common.UpdateContextWithForkPresets(contextNode, l1Preset, l2Preset); err != nil {
+			return fmt.Errorf("failed to apply fork preset: %w", err)
+		}
+		if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+			return fmt.Errorf("failed to save updated context: %v", err)
+		}
+
+		// Reload so the preset is reflected below
+		if config, contextName, err = common.LoadConfigWithContextConfig(contextName); err != nil {
+			return fmt.Errorf("loading config and context failed: %w", err)
+		}
+
+		presets := map[string]common.ChainPreset{common.L1: l1Preset}
+		if l2Preset != nil {
+			presets[common.L2] = *l2Preset
+		} else {
+			logger.Warn("%s has no paired L2 preset, the l2 keeps its fork settings from context", l1Preset.Name)
+		}
+		for _, name := range []string{common.L1, common.L2} {
+			preset, ok := presets[name]
+			if !ok {
+				continue
+			}
+			// Presets without a fork block keep the block in context
+			forkBlock := 0
+			if chain := config.Context[contextName].Chains[name]; chain.Fork != nil {
+				forkBlock = chain.Fork.Block
+			}
+			logger.Info("Forking %s (chain id %d) at %s, make sure its fork url points at %s", preset.Name, preset.ChainID, describeForkBlock(forkBlock), preset.Name)
+			if missing := preset.MissingEigenLayerAddresses(); len(missing) > 0 {
+				logger.Warn("No known %s deployment of %s, the addresses in context are kept, check them in config/contexts/%s.yaml", preset.Name, strings.Join(missing, ", "), contextName)
+			}
+		}
+	}
+
+	// Resolve --fork-block and record the pinned blocks in context so later starts fork the same state
+	if spec := cCtx.String("fork-block"); spec != "" {
+		if noFork || resume {
+			return fmt.Errorf("--fork-block cannot be combined with --no-fork or --resume")
+		}
+		if err := pinDevnetForkBlocks(cCtx, logger, config, contextName, contextNode, spec); err != nil {
+			return err
+		}
+		if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+			return fmt.Errorf("failed to save updated context: %v", err)
+		}
+
+		// Reload so the pinned blocks are reflected below
+		if config, contextName, err = common.LoadConfigWithContextConfig(contextName); err != nil {
+			return fmt.Errorf("loading config and context failed: %w", err)
+		}
+	}
+
 	// Extract context details
 	envCtx, ok := config.Context[contextName]
 	if !ok {
@@ -113,160 +185,166 @@ func StartDevnetAction(cCtx *cli.Context) error {
 			}
 		}
 	}
+	// Load the devnet state so that completed setup steps are skipped on --resume
+	var state *devnet.State
+	if resume {
+		state, err = devnet.ResumeState(contextName)
+		if errors.Is(err, devnet.ErrNoState) {
+			return fmt.Errorf("cannot resume: %w, start the devnet with --keep-on-failure so a failed setup can be resumed", err)
+		}
+	} else {
+		state, err = devnet.LoadState(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("loading devnet state failed: %w", err)
+	}
+
 	l1Port := cCtx.Int("l1-port")
 	l2Port := cCtx.Int("l2-port")
-
-	if !devnet.IsPortAvailable(l2Port) {
-		return fmt.Errorf("❌ Port %d is already in use. Please choose a different port using --l2-port", l2Port)
+	var l2Ports map[string]int
+
+	// Reuse the ports and mode recorded by the original run
+	if resume {
+		noFork = state.NoFork
+		autoPorts = state.AutoPorts
+		if state.L1Port != 0 {
+			l1Port = state.L1Port
+		}
+		if state.L2Port != 0 {
+			l2Port = state.L2Port
+		}
+		l2Ports = state.L2Ports
+	} else if autoPorts {
+		if l1Port, l2Port, l2Ports, err = allocateDevnetPorts(envCtx); err != nil {
+			return err
+		}
 	}
 
-	if !devnet.IsPortAvailable(l1Port) {
-		return fmt.Errorf("❌ Port %d is already in use. Please choose a different port using --l1-port", l1Port)
-	}
-	if !devnet.IsPortAvailable(l2Port) {
-		return fmt.Errorf("❌ L2 port %d is already in use. Please choose a different port using --port", l2Port)
-	}
+	// Containers are namespaced by project and context so several devnets can run side by side
+	namespace := devnet.Namespace(config.Config.Project.Name, contextName)
 
-	chainImage := devnet.GetDevnetChainImageOrDefault(config)
-	l1ChainArgs := devnet.GetL1DevnetChainArgsOrDefault(config)
-	l2ChainArgs := devnet.GetL2DevnetChainArgsOrDefault(config)
+	// Resolve the L1 and every L2 declared in context to their ports and containers
+	chains, err := resolveDevnetChains(envCtx, namespace, l1Port, l2Port, l2Ports)
+	if err != nil {
+		return err
+	}
 
 	// Start timer
 	startTime := time.Now()
 
-	logger.Info("Starting L1 and L2 devnets...\n")
+	// Stops the fork caching proxies (if any) once the devnet exits
+	stopForkCache := func() {}
+	defer func() { stopForkCache() }()
 
-	// Docker-compose for anvil devnet
-	composePath := devnet.WriteEmbeddedArtifacts()
-	l1ForkUrl, err := common.GetForkUrlDefault(contextName, config, common.L1)
-	if err != nil {
-		return fmt.Errorf("L1 fork URL error %w", err)
-	}
-	l2ForkUrl, err := common.GetForkUrlDefault(contextName, config, common.L2)
-	if err != nil {
-		return fmt.Errorf("L2 fork URL error: %w", err)
-	}
+	if resume {
+		// Resuming requires the containers from the failed run to still be up
+		for _, chain := range chains {
+			running, err := devnet.IsContainerRunning(cCtx.Context, chain.ContainerName)
+			if err != nil {
+				return err
+			}
+			if !running {
+				return fmt.Errorf("cannot resume: container %s is not running, start the devnet without --resume", chain.ContainerName)
+			}
+		}
+		logger.Info("Resuming devnet setup (%d steps already completed)...\n", len(state.CompletedSteps))
+	} else {
+		if err := checkDevnetPortsAvailable(chains, envCtx.Services); err != nil {
+			return err
+		}
 
-	// Error if the l1ForkUrl has not been modified
-	if l1ForkUrl == "" {
-		return fmt.Errorf("l1 fork-url not set; set l1 fork-url in ./config/context/devnet.yaml or .env and consult README for guidance")
-	}
-	// Error if the l2ForkUrl has not been modified
-	if l2ForkUrl == "" {
-		return fmt.Errorf("l2 fork-url not set; set l2 fork-url in ./config/context/devnet.yaml or .env and consult README for guidance")
-	}
+		// Catch pruned providers and fork blocks predating the EigenLayer deployment before anything starts
+		if !noFork && !cCtx.Bool("skip-fork-check") {
+			if err := checkDevnetForks(cCtx, logger, config, contextName, chains); err != nil {
+				return err
+			}
+		}
 
-	// Ensure fork URL uses appropriate Docker host for container environments
-	l1DockerForkUrl := devnet.EnsureDockerHost(l1ForkUrl)
-	l2DockerForkUrl := devnet.EnsureDockerHost(l2ForkUrl)
-	// Get the l1 block_time from env/config
-	l1BlockTime, err := devnet.GetDevnetBlockTimeOrDefault(config, common.L1)
-	if err != nil {
-		l1BlockTime = 12
-	}
+		if err := runDevnetHook(cCtx, logger, contextName, hookPreDevnetStart, nil); err != nil {
+			return err
+		}
 
-	// Get the l2 block_time from env/config
-	l2BlockTime, err := devnet.GetDevnetBlockTimeOrDefault(config, common.L2)
-	if err != nil {
-		l2BlockTime = 12
-	}
+		logger.Info("Starting L1 and %d L2 devnet(s)...\n", len(chains)-1)
 
-	// Get the l1 chain_id from env/config
-	l1ChainId, err := devnet.GetDevnetChainIdOrDefault(config, common.L1, logger)
-	if err != nil {
-		l1ChainId = devnet.DEFAULT_L1_ANVIL_CHAINID
-	}
+		stopForkCache, err = startDevnetContainers(cCtx, logger, config, contextName, chains, noFork)
+		if err != nil {
+			return err
+		}
+		if persist && cCtx.Bool("fork-cache") {
+			logger.Warn("The fork cache proxies stop when devkit exits, persisted containers will fail on fork reads that were not cached")
+		}
 
-	// Get the l2 chain_id from env/config
-	l2ChainId, err := devnet.GetDevnetChainIdOrDefault(config, common.L2, logger)
-	if err != nil {
-		l2ChainId = devnet.DEFAULT_L2_ANVIL_CHAINID
+		// Start a fresh checkpoint record for this devnet
+		state = &devnet.State{
+			Context:   contextName,
+			Project:   config.Config.Project.Name,
+			Namespace: namespace,
+			AutoPorts: autoPorts,
+			L1Port:    l1Port,
+			L2Port:    l2Port,
+			L2Ports:   extraL2Ports(chains),
+			NoFork:    noFork,
+		}
+		if err := devnet.SaveState(state); err != nil {
+			return err
+		}
 	}
 
-	// Append config defined details to chainArgs for l1
-	l1ChainArgs = fmt.Sprintf("%s --chain-id %d", l1ChainArgs, l1ChainId)
-	l1ChainArgs = fmt.Sprintf("%s --block-time %d", l1ChainArgs, l1BlockTime)
-
-	// Append config defined details to chainArgs for l2
-	l2ChainArgs = fmt.Sprintf("%s --chain-id %d", l2ChainArgs, l2ChainId)
-	l2ChainArgs = fmt.Sprintf("%s --block-time %d", l2ChainArgs, l2BlockTime)
-
-	// Run docker compose up for anvil devnet
-	cmd := exec.CommandContext(cCtx.Context, "docker", "compose", "-p", config.Config.Project.Name, "-f", composePath, "up", "-d")
-
-	l1ContainerName := fmt.Sprintf("devkit-devnet-l1-%s", config.Config.Project.Name)
-	l2ContainerName := fmt.Sprintf("devkit-devnet-l2-%s", config.Config.Project.Name)
-	l1ChainConfig, found := envCtx.Chains[common.L1]
-	if !found {
-		return fmt.Errorf("failed to find a chain with name: l1 in devnet.yaml")
-	}
-	l2ChainConfig, found := envCtx.Chains[common.L2]
-	if !found {
-		return fmt.Errorf("failed to find a chain with name: l2 in devnet.yaml")
-	}
-
-	cmd.Env = append(os.Environ(),
-		"FOUNDRY_IMAGE="+chainImage,
-		"L1_ANVIL_ARGS="+l1ChainArgs,
-		"L2_ANVIL_ARGS="+l2ChainArgs,
-		fmt.Sprintf("L1_DEVNET_PORT=%d", l1Port),
-		fmt.Sprintf("L2_DEVNET_PORT=%d", l2Port),
-		"L1_FORK_RPC_URL="+l1DockerForkUrl,
-		"L2_FORK_RPC_URL="+l2DockerForkUrl,
-		fmt.Sprintf("L1_FORK_BLOCK_NUMBER=%d", l1ChainConfig.Fork.Block),
-		fmt.Sprintf("L2_FORK_BLOCK_NUMBER=%d", l2ChainConfig.Fork.Block),
-		"L1_AVS_CONTAINER_NAME="+l1ContainerName,
-		"L2_AVS_CONTAINER_NAME="+l2ContainerName,
-	)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("❌ Failed to start devnet: %w", err)
+	// Record the container logs for post-mortem debugging, registered before the cleanup below so the
+	// containers' shutdown output is captured too
+	if cCtx.Bool("save-logs") {
+		logDir := filepath.Join(devnet.LogsDir, startTime.Format("20060102-150405"))
+		logCtx, stopLogs := context.WithCancel(context.Background())
+		logsDone := make(chan struct{})
+		go func() {
+			defer close(logsDone)
+			if err := devnet.RecordDevnetLogs(logCtx, logger, logDir, config.Config.Project.Name, contextName); err != nil {
+				logger.Warn("Failed to save devnet logs: %v", err)
+			}
+		}()
+		defer func() {
+			stopLogs()
+			<-logsDone
+			logger.Info("Devnet logs saved to %s", logDir)
+		}()
+		logger.Info("Writing devnet logs to %s", logDir)
 	}
 
+	// Set when a setup step fails, with --keep-on-failure the containers are then left up for `devnet start --resume`
+	setupFailed := false
+
 	// On cancel, stop the containers if we're not skipping deployContracts/avsRun and we're not persisting
 	if !skipDeployContracts && !skipAvsRun && !persist {
 		defer func() {
+			if setupFailed {
+				if cCtx.Bool("keep-on-failure") {
+					logger.Warn("Devnet setup failed, leaving containers running. Fix the issue and run `devkit avs devnet start --resume` to continue, or `devkit avs devnet stop` to clean up")
+					return
+				}
+				logger.Warn("Devnet setup failed, start with --keep-on-failure to leave the containers running for `devkit avs devnet start --resume`")
+			}
+
 			logger.Info("Stopping containers")
 			// Use background context to avoid cancellation issues during cleanup
 			bgCtx := context.Background()
 
-			l1Container := fmt.Sprintf("devkit-devnet-l1-%s", config.Config.Project.Name)
-			l2Container := fmt.Sprintf("devkit-devnet-l2-%s", config.Config.Project.Name)
+			if err := runDevnetHook(&cli.Context{Context: bgCtx}, logger, contextName, hookPreDevnetStop, state); err != nil {
+				logger.Warn("%v", err)
+			}
+			stopProjectContainers(&cli.Context{Context: bgCtx}, logger, config.Config.Project.Name, contextName)
 
-			logger.Info("Stopping individual containers: %s, %s", l1Container, l2Container)
-			devnet.StopAndRemoveContainer(&cli.Context{Context: bgCtx}, l1Container)
-			devnet.StopAndRemoveContainer(&cli.Context{Context: bgCtx}, l2Container)
+			// Forget the recorded ports so other commands stop targeting the removed containers
+			if err := devnet.RemoveState(contextName); err != nil {
+				logger.Warn("%v", err)
+			}
 		}()
 	}
 
-	// Construct RPC url to pass to scripts for l1 and l2
-	l1RpcUrl := devnet.GetRPCURL(l1Port)
-	l2RpcUrl := devnet.GetRPCURL(l2Port)
 	logger.Info("Waiting for devnet to be ready...")
 
-	// Get chains node
-	chainsNode := common.GetChildByKey(contextNode, "chains")
-	if chainsNode == nil {
-		return fmt.Errorf("missing 'chains' key in context")
-	}
-
-	// Update RPC URLs for L1 chain
-	l1ChainNode := common.GetChildByKey(chainsNode, common.L1)
-	if l1ChainNode != nil {
-		l1RpcUrlNode := common.GetChildByKey(l1ChainNode, "rpc_url")
-		if l1RpcUrlNode != nil {
-			l1RpcUrlNode.Value = l1RpcUrl
-		}
-	}
-
-	// Update RPC URLs for L2 chain
-	l2ChainNode := common.GetChildByKey(chainsNode, common.L2)
-	if l2ChainNode != nil {
-		l2RpcUrlNode := common.GetChildByKey(l2ChainNode, "rpc_url")
-		if l2RpcUrlNode != nil {
-			l2RpcUrlNode.Value = l2RpcUrl
-		}
+	// Point the context at the devnet RPC urls
+	if err := setContextRPCURLs(contextNode, devnetRPCURLs(chains)); err != nil {
+		return err
 	}
 
 	// Write yaml back to project directory
@@ -274,117 +352,56 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		return err
 	}
 
-	// Sleep for 4 second to ensure the devnet is fully started
-	time.Sleep(4 * time.Second)
-
-	// Fund the wallets defined in config on L1
-	logger.Info("Funding wallets on L1...")
-	err = devnet.FundWalletsDevnet(config, l1RpcUrl)
-	if err != nil {
-		return fmt.Errorf("funding L1 devnet wallets failed: %w", err)
-	}
-
-	// Fund the wallets defined in config on L2
-	logger.Info("Funding wallets on L2...")
-	err = devnet.FundWalletsDevnet(config, l2RpcUrl)
-	if err != nil {
-		return fmt.Errorf("failed L2 devnet wallets failed: %w", err)
+	// Wait until every chain answers and has reached its fork block
+	if err := waitForDevnetReady(cCtx, logger, config, chains, noFork); err != nil {
+		return err
 	}
 
-	// Fund stakers with strategy tokens
-	if contextName == devnet.DEVNET_CONTEXT {
-		logger.Info("Funding stakers with strategy tokens...")
-
-		var tokenAddresses []string
-		var tokenErr error
-		tokenAddresses, tokenErr = devnet.GetUnderlyingTokenAddressesFromStrategies(config, l1RpcUrl, logger)
-		if tokenErr != nil {
-			logger.Warn("Failed to get underlying token addresses from strategies: %v", tokenErr)
-			logger.Info("Continuing with devnet startup...")
+	// Without a fork there is no EigenLayer deployment to build on, so deploy the core contracts first
+	if noFork {
+		if err := runSetupPipeline(cCtx, logger, []SetupStep{bootstrapEigenLayerStep()}, state); err != nil {
+			setupFailed = true
+			return err
 		}
 
-		if len(tokenAddresses) > 0 {
-			err = devnet.FundStakersWithStrategyTokens(config, l1RpcUrl, tokenAddresses)
-			if err != nil {
-				logger.Warn("Failed to fund stakers with strategy tokens: %v", err)
-				logger.Info("Continuing with devnet startup...")
-			}
-		} else {
-			logger.Info("No tokens to fund stakers with, skipping token funding")
+		// The stake table calculators are not bundled, so stake roots cannot be generated offline
+		if !skipTransporter {
+			logger.Warn("Stake root transport is not available with --no-fork, skipping transporter")
+			skipTransporter = true
 		}
-	} else {
-		logger.Info("Skipping token funding for non-devnet context")
+	}
+
+	// Fund the wallets and stakers defined in config
+	if err := runSetupPipeline(cCtx, logger, devnetFundingSteps(), state); err != nil {
+		setupFailed = true
+		return err
 	}
 
 	elapsed := time.Since(startTime).Round(time.Second)
 
-	// Sleep for 1 second to make sure wallets are funded
-	time.Sleep(1 * time.Second)
-	logger.Info("\nL1 devnet started successfully on port %d", l1Port)
-	logger.Info("L2 devnet started successfully on port %d", l2Port)
+	for _, chain := range chains {
+		logger.Info("%s devnet started successfully on port %d", strings.ToUpper(chain.Name), chain.Port)
+	}
 	logger.Info("Total startup time: %s", elapsed)
 
-	if err := WhitelistChainIdInCrossRegistryAction(cCtx, logger); err != nil {
-		return fmt.Errorf("whitelisting chain id in cross registry failed: %w", err)
+	if err := runSetupPipeline(cCtx, logger, []SetupStep{whitelistChainIdStep()}, state); err != nil {
+		setupFailed = true
+		return err
 	}
 
 	// Deploy the contracts after starting devnet unless skipped
 	if !skipDeployContracts {
-		if err := DeployL1ContractsAction(cCtx); err != nil { // Assumes DeployContractsAction remains as is or is also refactored if needed
-			return fmt.Errorf("deploy-contracts failed: %w", err)
+		if err := runSetupPipeline(cCtx, logger, []SetupStep{deployL1ContractsStep(), devnetHookStep(contextName, hookPostContractsDeployed, state)}, state); err != nil {
+			setupFailed = true
+			return err
 		}
 
-		// Sleep for 1 second to make sure new context values have been written
-		time.Sleep(1 * time.Second)
-
 		logger.Title("Registering AVS with EigenLayer...")
 		if !cCtx.Bool("skip-setup") {
-			if err := UpdateAVSMetadataAction(cCtx, logger); err != nil {
-				return fmt.Errorf("updating AVS metadata failed: %w", err)
-			}
-
-			if err := SetAVSRegistrarAction(cCtx, logger); err != nil {
-				return fmt.Errorf("setting AVS registrar failed: %w", err)
-			}
-
-			if err := CreateAVSOperatorSetsAction(cCtx, logger); err != nil {
-				return fmt.Errorf("creating AVS operator sets failed: %w", err)
-			}
-
-			if err := ConfigureOpSetCurveTypeAction(cCtx, logger); err != nil {
-				return fmt.Errorf("failed to configure OpSet in KeyRegistrar: %w", err)
-			}
-
-			if err := CreateGenerationReservationAction(cCtx, logger); err != nil {
-				return fmt.Errorf("failed to request op set generation reservation: %w", err)
-			}
-
-			if err := RegisterOperatorsToEigenLayerFromConfigAction(cCtx, logger); err != nil {
-				return fmt.Errorf("registering operators failed: %w", err)
-			}
-
-			if err := RegisterKeyInKeyRegistrarAction(cCtx, logger); err != nil {
-				return fmt.Errorf("registering key in key registrar failed: %w", err)
-			}
-
-			if err := DepositIntoStrategiesAction(cCtx, logger); err != nil {
-				return fmt.Errorf("depositing into strategies failed: %w", err)
-			}
-
-			if err := DelegateToOperatorsAction(cCtx, logger); err != nil {
-				return fmt.Errorf("delegating to operators failed: %w", err)
-			}
-
-			if err := SetAllocationDelayAction(cCtx, logger); err != nil {
-				return fmt.Errorf("setting allocation delay failed: %w", err)
-			}
-
-			if err := ModifyAllocationsAction(cCtx, logger); err != nil {
-				return fmt.Errorf("modifying allocations failed: %w", err)
-			}
-
-			if err := RegisterOperatorsToAvsFromConfigAction(cCtx, logger); err != nil {
-				return fmt.Errorf("registering operators to AVS failed: %w", err)
+			steps := append(avsSetupSteps(true), devnetHookStep(contextName, hookPostOperatorsRegistered, state))
+			if err := runSetupPipeline(cCtx, logger, steps, state); err != nil {
+				setupFailed = true
+				return err
 			}
 		} else {
 			logger.Info("Skipping AVS setup steps...")
@@ -419,9 +436,6 @@ func StartDevnetAction(cCtx *cli.Context) error {
 		}()
 	}
 
-	// Sleep for 2 seconds
-	time.Sleep(2 * time.Second)
-
 	// Deploy L2 contracts only if L1 contracts were also deployed
 	if !skipDeployContracts {
 		if err := DeployL2ContractsAction(cCtx); err != nil && !errors.Is(err, context.Canceled) {
@@ -449,6 +463,156 @@ func StartDevnetAction(cCtx *cli.Context) error {
 	return ctx.Err()
 }
 
+// startDevnetContainers brings up an anvil container per devnet chain, and the extra services declared in context, via docker compose.
+// With noFork the chains start empty instead of forking the configured fork urls. With --fork-cache the forks are
+// served through local caching proxies, which are stopped by the returned func.
+func startDevnetContainers(cCtx *cli.Context, logger iface.Logger, config *common.ConfigWithContextConfig, contextName string, chains []devnetChain, noFork bool) (func(), error) {
+	proxies := map[string]*devnet.ForkCacheProxy{}
+	stopProxies := func() {
+		for name, proxy := range proxies {
+			hits, misses := proxy.Stats()
+			logger.Info("%s fork cache: %d cached, %d fetched from upstream", name, hits, misses)
+			_ = proxy.Close()
+		}
+	}
+
+	composeChains := make([]devnet.ComposeChain, 0, len(chains))
+	for _, chain := range chains {
+		opts := devnet.ChainStartOptions{}
+
+		// The default chain args are anvil flags
+		if chain.Backend.Name() == devnet.AnvilBackendName {
+			opts.Args = devnet.GetL2DevnetChainArgsOrDefault(config)
+			if chain.isL1() {
+				opts.Args = devnet.GetL1DevnetChainArgsOrDefault(config)
+			}
+		}
+
+		// Fork options are left empty when running offline
+		if !noFork {
+			forkUrl, err := common.GetForkUrlDefault(contextName, config, chain.Name)
+			if err != nil {
+				return stopProxies, fmt.Errorf("%s fork URL error: %w", chain.Name, err)
+			}
+
+			// Error if the fork url has not been modified
+			if forkUrl == "" {
+				return stopProxies, fmt.Errorf("%s fork-url not set; set %s fork-url in ./config/context/devnet.yaml or .env and consult README for guidance, or start with --no-fork", chain.Name, chain.Name)
+			}
+			forkBlock := 0
+			if chain.Config.Fork != nil {
+				forkBlock = chain.Config.Fork.Block
+			}
+
+			// Serve the fork through a caching proxy so repeated starts at the same block are answered from disk
+			if cCtx.Bool("fork-cache") {
+				if forkBlock == 0 {
+					return stopProxies, fmt.Errorf("--fork-cache needs a pinned fork block, set %s fork.block in ./config/context/devnet.yaml", chain.Name)
+				}
+				proxy, err := devnet.StartForkCacheProxy(forkUrl, devnet.RPCCachePath(chain.Name, forkUrl, forkBlock), devnet.ForkCacheProxyAddr())
+				if err != nil {
+					return stopProxies, fmt.Errorf("failed to start %s fork cache: %w", chain.Name, err)
+				}
+				proxies[chain.Name] = proxy
+				logger.Info("Serving the %s fork through a caching proxy on port %d", chain.Name, proxy.Port())
+				forkUrl = devnet.GetRPCURL(proxy.Port())
+			}
+
+			// Ensure fork URL uses appropriate Docker host for container environments
+			opts.ForkURL = devnet.EnsureDockerHost(forkUrl)
+			opts.ForkBlock = forkBlock
+		}
+
+		// Get the block_time from env/config
+		blockTime, err := devnet.GetDevnetBlockTimeOrDefault(config, chain.Name)
+		if err != nil {
+			blockTime = 12
+		}
+		opts.BlockTime = blockTime
+
+		// Get the chain_id from env/config
+		chainId, err := devnet.GetDevnetChainIdOrDefault(config, chain.Name, logger)
+		if err != nil {
+			chainId = devnet.DEFAULT_L2_ANVIL_CHAINID
+			if chain.isL1() {
+				chainId = devnet.DEFAULT_L1_ANVIL_CHAINID
+			}
+		}
+		opts.ChainID = chainId
+
+		// Anvil chains keep the configurable foundry image
+		image := chain.Backend.DefaultImage()
+		if chain.Backend.Name() == devnet.AnvilBackendName {
+			image = devnet.GetDevnetChainImageOrDefault(config)
+		}
+
+		entrypoint, command := chain.Backend.ContainerCommand(opts)
+		composeChains = append(composeChains, devnet.ComposeChain{
+			Name:          chain.Name,
+			ContainerName: chain.ContainerName,
+			Port:          chain.Port,
+			Image:         image,
+			Entrypoint:    entrypoint,
+			Command:       command,
+		})
+	}
+
+	// Docker-compose for the devnet chains
+	composePath, err := devnet.WriteDockerCompose(devnet.ComposeConfig{
+		Project:  config.Config.Project.Name,
+		Context:  contextName,
+		Image:    devnet.GetDevnetChainImageOrDefault(config),
+		Chains:   composeChains,
+		Services: config.Context[contextName].Services,
+	})
+	if err != nil {
+		return stopProxies, err
+	}
+
+	// Run docker compose up for anvil devnet
+	cmd := exec.CommandContext(cCtx.Context, "docker", "compose", "-p", devnet.Namespace(config.Config.Project.Name, contextName), "-f", composePath, "up", "-d")
+	var composeOutput bytes.Buffer
+	if cCtx.Bool("headless") {
+		// Only shown when compose fails
+		cmd.Stdout = &composeOutput
+		cmd.Stderr = &composeOutput
+	} else {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Run(); err != nil {
+		if composeOutput.Len() > 0 {
+			logger.Error("%s", composeOutput.String())
+		}
+		return stopProxies, fmt.Errorf("❌ Failed to start devnet: %w", err)
+	}
+
+	return stopProxies, nil
+}
+
+// setContextRPCURLs updates the rpc_url of each chain in the given context node
+func setContextRPCURLs(contextNode *yaml.Node, rpcUrls map[string]string) error {
+	// Get chains node
+	chainsNode := common.GetChildByKey(contextNode, "chains")
+	if chainsNode == nil {
+		return fmt.Errorf("missing 'chains' key in context")
+	}
+
+	// Update RPC URLs for each chain
+	for chainName, rpcUrl := range rpcUrls {
+		chainNode := common.GetChildByKey(chainsNode, chainName)
+		if chainNode == nil {
+			continue
+		}
+		rpcUrlNode := common.GetChildByKey(chainNode, "rpc_url")
+		if rpcUrlNode != nil {
+			rpcUrlNode.Value = rpcUrl
+		}
+	}
+
+	return nil
+}
+
 func StopDevnetAction(cCtx *cli.Context) error {
 	// Get logger
 	log := common.LoggerFromContext(cCtx.Context)
@@ -500,12 +664,8 @@ func StopDevnetAction(cCtx *cli.Context) error {
 	// Check if any of the args are provided
 	if !(projectName == "") || !(projectPort == 0) || !(l1Port == 0) || !(l2Port == 0) {
 		if projectName != "" {
-			// Stop both L1 and L2 containers
-			l1Container := fmt.Sprintf("devkit-devnet-l1-%s", projectName)
-			l2Container := fmt.Sprintf("devkit-devnet-l2-%s", projectName)
-
-			devnet.StopAndRemoveContainer(cCtx, l1Container)
-			devnet.StopAndRemoveContainer(cCtx, l2Container)
+			// Stop the L1 and every L2 container of each of the project's devnets
+			stopProjectContainers(cCtx, log, projectName, "")
 		} else if l1Port != 0 {
 			// Stop only L1 container matching the port
 			stopContainerByPort(cCtx, log, l1Port, "l1")
@@ -524,20 +684,27 @@ func StopDevnetAction(cCtx *cli.Context) error {
 		var err error
 		var config *common.ConfigWithContextConfig
 		if contextName == "" {
-			config, _, err = common.LoadDefaultConfigWithContextConfig()
+			config, contextName, err = common.LoadDefaultConfigWithContextConfig()
 		} else {
-			config, _, err = common.LoadConfigWithContextConfig(contextName)
+			config, contextName, err = common.LoadConfigWithContextConfig(contextName)
 		}
 		if err != nil {
 			return fmt.Errorf("loading config and context failed: %w", err)
 		}
 
-		// Stop both L1 and L2 containers
-		l1Container := fmt.Sprintf("devkit-devnet-l1-%s", config.Config.Project.Name)
-		l2Container := fmt.Sprintf("devkit-devnet-l2-%s", config.Config.Project.Name)
+		// Give the project a chance to export or clean up state while the chains are still up
+		state, err := devnet.LoadState(contextName)
+		if err != nil {
+			return err
+		}
+		if err := runDevnetHook(cCtx, log, contextName, hookPreDevnetStop, state); err != nil {
+			log.Warn("%v", err)
+		}
 
-		devnet.StopAndRemoveContainer(cCtx, l1Container)
-		devnet.StopAndRemoveContainer(cCtx, l2Container)
+		// Stop the devnet recorded for this project and context
+		if err := stopRecordedDevnet(cCtx, log, config.Config.Project.Name, contextName); err != nil {
+			return err
+		}
 	} else {
 		log.Info("Run this command from the avs directory  or run %sdevkit avs devnet stop --help%s for available commands", devnet.Cyan, devnet.Reset)
 	}
@@ -546,31 +713,61 @@ func StopDevnetAction(cCtx *cli.Context) error {
 }
 
 func ListDevnetContainersAction(cCtx *cli.Context) error {
-	cmd := exec.CommandContext(cCtx.Context, "docker", devnet.GetDockerPsDevnetArgs()...)
-	output, err := cmd.Output()
+	output := cCtx.String("output")
+	if output != "table" && output != "json" && output != "yaml" {
+		return fmt.Errorf("unsupported output format %q, use table, json or yaml", output)
+	}
+
+	containers, err := devnet.ListDevnetContainers(cCtx.Context)
 	if err != nil {
-		return fmt.Errorf("failed to list devnet containers: %w", err)
+		return err
 	}
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
-		fmt.Printf("%s🚫 No devnet containers running.%s\n", devnet.Yellow, devnet.Reset)
-		return nil
+	if containers == nil {
+		containers = []devnet.DevnetContainer{}
 	}
-	fmt.Printf("%s📦 Running Devnet Containers:%s\n\n", devnet.Blue, devnet.Reset)
-	for _, line := range lines {
-		parts := strings.Split(line, ": ")
-		if len(parts) != 2 {
-			continue
+
+	w := cCtx.App.Writer
+	switch output {
+	case "json":
+		data, err := json.MarshalIndent(containers, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode devnet containers: %w", err)
 		}
-		name := parts[0]
-		port := extractHostPort(parts[1])
-		fmt.Printf("%s  -  %s%-25s%s %s→%s  %shttp://localhost:%s%s\n",
-			devnet.Cyan, devnet.Reset,
-			name,
-			devnet.Reset,
-			devnet.Green, devnet.Reset,
-			devnet.Yellow, port, devnet.Reset,
-		)
+		fmt.Fprintln(w, string(data))
+	case "yaml":
+		data, err := yaml.Marshal(containers)
+		if err != nil {
+			return fmt.Errorf("failed to encode devnet containers: %w", err)
+		}
+		fmt.Fprint(w, string(data))
+	default:
+		if len(containers) == 0 {
+			fmt.Fprintf(w, "%s🚫 No devnet containers running.%s\n", devnet.Yellow, devnet.Reset)
+			return nil
+		}
+		fmt.Fprintf(w, "%s📦 Running Devnet Containers:%s\n\n", devnet.Blue, devnet.Reset)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "PROJECT\tCONTEXT\tROLE\tCONTAINER\tPORT\tRPC URL\tIMAGE\tUPTIME")
+		for _, c := range containers {
+			role := c.Role
+			if c.Service != "" {
+				role = fmt.Sprintf("%s (%s)", c.Role, c.Service)
+			}
+			port := "-"
+			if c.HostPort != 0 {
+				port = strconv.Itoa(c.HostPort)
+			}
+			rpcURL := c.RPCURL
+			if rpcURL == "" {
+				rpcURL = "-"
+			}
+			contextName := c.Context
+			if contextName == "" {
+				contextName = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.Project, contextName, role, c.Name, port, rpcURL, c.Image, c.Uptime)
+		}
+		return tw.Flush()
 	}
 	return nil
 }
@@ -582,11 +779,7 @@ func DepositIntoStrategiesAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Load config for selected context
 	var cfg *common.ConfigWithContextConfig
 	var err error
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for deposit into strategies: %w", err)
 	}
@@ -597,10 +790,24 @@ func DepositIntoStrategiesAction(cCtx *cli.Context, logger iface.Logger) error {
 		return fmt.Errorf("context '%s' not found in configuration", contextName)
 	}
 
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	txManager := newContextTxManager(client, envCtx, logger)
 	logger.Info("Depositing into strategies...")
 	for _, stakerSpec := range envCtx.Stakers {
+		if !isOperatorSelected(cCtx, stakerSpec.OperatorAddress) {
+			continue
+		}
 		logger.Info("Depositing into strategies for staker %s", stakerSpec.StakerAddress)
-		if err := depositIntoStrategy(cCtx, stakerSpec, logger); err != nil {
+		if err := depositIntoStrategy(cCtx, cfg, contextName, client, txManager, stakerSpec, logger); err != nil {
 			logger.Error("Failed to deposit into strategies for staker %s: %v. Continuing...", stakerSpec.StakerAddress, err)
 			continue
 		}
@@ -616,11 +823,7 @@ func DelegateToOperatorsAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Load config according to provided contextName
 	var err error
 	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for delegate to operators: %w", err)
 	}
@@ -633,12 +836,41 @@ func DelegateToOperatorsAction(cCtx *cli.Context, logger iface.Logger) error {
 
 	logger.Info("Delegating to operators...")
 
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	// Each staker signs its own delegation, so the delegations are sent together and mined in parallel
+	txManager := newContextTxManager(client, envCtx, logger)
+	var reqs []common.TxRequest
+	var stakers []string
 	for _, stakerSpec := range envCtx.Stakers {
+		if !isOperatorSelected(cCtx, stakerSpec.OperatorAddress) {
+			continue
+		}
 		logger.Info("Delegating to operators for staker %s", stakerSpec.StakerAddress)
-		if err := delegateToOperator(cCtx, stakerSpec, ethcommon.HexToAddress(stakerSpec.OperatorAddress), logger); err != nil {
+		req, err := delegateToOperatorTx(cCtx.Context, cfg, contextName, client, txManager, stakerSpec, ethcommon.HexToAddress(stakerSpec.OperatorAddress), logger)
+		if err != nil {
 			logger.Error("Failed to delegate to operators for staker %s: %v. Continuing...", stakerSpec.StakerAddress, err)
 			continue
 		}
+		if req != nil {
+			reqs = append(reqs, *req)
+			stakers = append(stakers, stakerSpec.StakerAddress)
+		}
+	}
+
+	results := txManager.SendAll(cCtx.Context, reqs)
+	for i, result := range results {
+		if result.Err != nil {
+			logger.Error("Failed to delegate to operators for staker %s: %v. Continuing...", stakers[i], result.Err)
+		}
 	}
 	logger.Info("Delegating to operators completed.")
 	return nil
@@ -653,41 +885,25 @@ func extractHostPort(portStr string) string {
 	return portStr
 }
 
-func registerOperatorEL(cCtx *cli.Context, operatorAddress string, logger iface.Logger) error {
-	// Extract vars
-	contextName := cCtx.String("context")
+// newContextTxManager returns a TxManager configured from the context, for the contract callers of an action to share
+func newContextTxManager(client *ethclient.Client, envCtx common.ChainContextConfig, logger iface.Logger) *common.TxManager {
+	txManager := common.NewTxManager(client, logger)
+	txManager.ConfigureFromContext(envCtx)
+	return txManager
+}
 
+// registerOperatorELTx returns the operator's RegisterAsOperator transaction signed with its key from context,
+// nil when the operator is already registered
+func registerOperatorELTx(cfg *common.ConfigWithContextConfig, contextName string, client *ethclient.Client, txManager *common.TxManager, operatorAddress string, logger iface.Logger) (*common.TxRequest, error) {
 	if operatorAddress == "" {
-		return fmt.Errorf("operatorAddress parameter is required and cannot be empty")
-	}
-
-	// Load config according to provided contextName
-	var err error
-	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to load configurations: %w", err)
+		return nil, fmt.Errorf("operatorAddress parameter is required and cannot be empty")
 	}
 
-	// Extract context details
-	envCtx, ok := cfg.Context[contextName]
-	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", contextName)
-	}
+	envCtx := cfg.Context[contextName]
 	l1Cfg, ok := envCtx.Chains[common.L1]
 	if !ok {
-		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
-	}
-
-	client, err := ethclient.Dial(l1Cfg.RPCURL)
-	if err != nil {
-		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+		return nil, fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
-	defer client.Close()
 
 	var operatorPrivateKey string
 	var foundOperator bool
@@ -709,7 +925,7 @@ func registerOperatorEL(cCtx *cli.Context, operatorAddress string, logger iface.
 		}
 	}
 	if !foundOperator {
-		return fmt.Errorf("operator with address %s not found in config", operatorAddress)
+		return nil, fmt.Errorf("operator with address %s not found in config", operatorAddress)
 	}
 	allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
 
@@ -727,50 +943,28 @@ func registerOperatorEL(cCtx *cli.Context, operatorAddress string, logger iface.
 		logger,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create contract caller: %w", err)
+		return nil, fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(txManager)
 
-	return contractCaller.RegisterAsOperator(cCtx.Context, ethcommon.HexToAddress(operatorAddress), 0, "test")
+	return contractCaller.RegisterAsOperatorTx(ethcommon.HexToAddress(operatorAddress), 0, "test")
 }
 
-func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress string, operatorSetID uint32, payloadHex string) error {
-	// Extract vars
-	contextName := cCtx.String("context")
-
+// registerOperatorAVSTx returns the operator's RegisterForOperatorSets transaction signed with its key from context,
+// nil when the operator is already registered to the operator set
+func registerOperatorAVSTx(ctx context.Context, cfg *common.ConfigWithContextConfig, contextName string, client *ethclient.Client, txManager *common.TxManager, operatorAddress string, operatorSetID uint32, payloadHex string, logger iface.Logger) (*common.TxRequest, error) {
 	if operatorAddress == "" {
-		return fmt.Errorf("operatorAddress parameter is required and cannot be empty")
+		return nil, fmt.Errorf("operatorAddress parameter is required and cannot be empty")
 	}
 	if payloadHex == "" {
-		return fmt.Errorf("payloadHex parameter is required and cannot be empty")
+		return nil, fmt.Errorf("payloadHex parameter is required and cannot be empty")
 	}
 
-	// Load config according to provided contextName
-	var err error
-	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to load configurations: %w", err)
-	}
-
-	// Extract context details
-	envCtx, ok := cfg.Context[contextName]
-	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", contextName)
-	}
+	envCtx := cfg.Context[contextName]
 	l1Cfg, ok := envCtx.Chains[common.L1]
 	if !ok {
-		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
-	}
-
-	client, err := ethclient.Dial(l1Cfg.RPCURL)
-	if err != nil {
-		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+		return nil, fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
-	defer client.Close()
 
 	var operatorPrivateKey string
 	var foundOperator bool
@@ -792,7 +986,7 @@ func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress
 		}
 	}
 	if !foundOperator {
-		return fmt.Errorf("operator with address %s not found in config", operatorAddress)
+		return nil, fmt.Errorf("operator with address %s not found in config", operatorAddress)
 	}
 
 	allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
@@ -811,16 +1005,17 @@ func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress
 		logger,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create contract caller: %w", err)
+		return nil, fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(txManager)
 
 	payloadBytes, err := hex.DecodeString(payloadHex)
 	if err != nil {
-		return fmt.Errorf("failed to decode payload hex '%s': %w", payloadHex, err)
+		return nil, fmt.Errorf("failed to decode payload hex '%s': %w", payloadHex, err)
 	}
 
-	return contractCaller.RegisterForOperatorSets(
-		cCtx.Context,
+	return contractCaller.RegisterForOperatorSetsTx(
+		ctx,
 		ethcommon.HexToAddress(operatorAddress),
 		ethcommon.HexToAddress(envCtx.Avs.Address),
 		[]uint32{operatorSetID},
@@ -828,43 +1023,17 @@ func registerOperatorAVS(cCtx *cli.Context, logger iface.Logger, operatorAddress
 	)
 }
 
-func depositIntoStrategy(cCtx *cli.Context, stakerSpec common.StakerSpec, logger iface.Logger) error {
-	// Extract vars
-	contextName := cCtx.String("context")
-
+func depositIntoStrategy(cCtx *cli.Context, cfg *common.ConfigWithContextConfig, contextName string, client *ethclient.Client, txManager *common.TxManager, stakerSpec common.StakerSpec, logger iface.Logger) error {
 	if stakerSpec.StakerAddress == "" {
 		return fmt.Errorf("staker address parameter is required and cannot be empty")
 	}
 
-	// Load config according to provided contextName
-	var err error
-	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to load configurations: %w", err)
-	}
-
-	// Extract context details
-	envCtx, ok := cfg.Context[contextName]
-	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", contextName)
-	}
-
+	envCtx := cfg.Context[contextName]
 	l1Cfg, ok := envCtx.Chains[common.L1]
 	if !ok {
 		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
 
-	client, err := ethclient.Dial(l1Cfg.RPCURL)
-	if err != nil {
-		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
-	}
-	defer client.Close()
-
 	allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
 	stakerPrivateKey := strings.TrimPrefix(stakerSpec.StakerECDSAKey, "0x")
 
@@ -884,6 +1053,7 @@ func depositIntoStrategy(cCtx *cli.Context, stakerSpec common.StakerSpec, logger
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(txManager)
 
 	for _, deposit := range stakerSpec.Deposits {
 		strategyAddress := deposit.StrategyAddress
@@ -900,38 +1070,14 @@ func depositIntoStrategy(cCtx *cli.Context, stakerSpec common.StakerSpec, logger
 	return nil
 }
 
-func delegateToOperator(cCtx *cli.Context, stakerSpec common.StakerSpec, operator ethcommon.Address, logger iface.Logger) error {
-	// Extract vars
-	contextName := cCtx.String("context")
-
-	// Load config according to provided contextName
-	var err error
-	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to load configurations: %w", err)
-	}
-
-	// Extract context details
-	envCtx, ok := cfg.Context[contextName]
-	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", contextName)
-	}
-
-	l1Cfg, ok := envCtx.Chains[common.L1]
-	if !ok {
-		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
-	}
-
-	client, err := ethclient.Dial(l1Cfg.RPCURL)
-	if err != nil {
-		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+// delegateToOperatorTx returns the staker's DelegateTo transaction, approved with the operator's key from context,
+// nil when the staker is already delegated to the operator
+func delegateToOperatorTx(ctx context.Context, cfg *common.ConfigWithContextConfig, contextName string, client *ethclient.Client, txManager *common.TxManager, stakerSpec common.StakerSpec, operator ethcommon.Address, logger iface.Logger) (*common.TxRequest, error) {
+	envCtx := cfg.Context[contextName]
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return nil, fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
-	defer client.Close()
 
 	allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
 	stakerPrivateKey := strings.TrimPrefix(stakerSpec.StakerECDSAKey, "0x")
@@ -950,8 +1096,9 @@ func delegateToOperator(cCtx *cli.Context, stakerSpec common.StakerSpec, operato
 		logger,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create contract caller: %w", err)
+		return nil, fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(txManager)
 	// After depositing, delegate to the operator
 	// Extract the private key of the operator we are delegating to in order to create an approval signature
 	var operatorPrivateKey string
@@ -960,7 +1107,7 @@ func delegateToOperator(cCtx *cli.Context, stakerSpec common.StakerSpec, operato
 		if strings.EqualFold(op.Address, operator.Hex()) {
 			keyHex, err := loadOperatorECDSAKey(op)
 			if err != nil {
-				return fmt.Errorf("failed to load ECDSA key for operator %s: %w", operator, err)
+				return nil, fmt.Errorf("failed to load ECDSA key for operator %s: %w", operator, err)
 			}
 			operatorPrivateKey = keyHex
 			foundOperator = true
@@ -968,7 +1115,7 @@ func delegateToOperator(cCtx *cli.Context, stakerSpec common.StakerSpec, operato
 		}
 	}
 	if !foundOperator {
-		return fmt.Errorf("ECDSA key not found for operator %s in operators in config. This means we cannot create an approval signature for this delegation", operator)
+		return nil, fmt.Errorf("ECDSA key not found for operator %s in operators in config. This means we cannot create an approval signature for this delegation", operator)
 	}
 
 	// expiry is 10 minutes from now
@@ -978,19 +1125,20 @@ func delegateToOperator(cCtx *cli.Context, stakerSpec common.StakerSpec, operato
 	salt := [32]byte{}
 	_, err = rand.Read(salt[:])
 	if err != nil {
-		return fmt.Errorf("failed to generate random salt: %w", err)
+		return nil, fmt.Errorf("failed to generate random salt: %w", err)
 	}
 
 	// Create the approval signature
-	signature, err := contractCaller.CreateApprovalSignature(cCtx.Context, ethcommon.HexToAddress(stakerSpec.StakerAddress), operator, operator, operatorPrivateKey, salt, expiry)
+	signature, err := contractCaller.CreateApprovalSignature(ctx, ethcommon.HexToAddress(stakerSpec.StakerAddress), operator, operator, operatorPrivateKey, salt, expiry)
 	if err != nil {
-		return fmt.Errorf("failed to create approval signature: %w", err)
+		return nil, fmt.Errorf("failed to create approval signature: %w", err)
 	}
 
-	if err := contractCaller.DelegateToOperator(cCtx.Context, operator, signature, salt); err != nil {
-		return fmt.Errorf("failed to delegate to operator: %w", err)
+	req, err := contractCaller.DelegateToOperatorTx(ctx, operator, signature, salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delegate to operator: %w", err)
 	}
-	return nil
+	return req, nil
 }
 
 func ModifyAllocationsAction(cCtx *cli.Context, logger iface.Logger) error {
@@ -1000,11 +1148,7 @@ func ModifyAllocationsAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Load config according to provided contextName
 	var err error
 	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for modify allocations: %w", err)
 	}
@@ -1015,7 +1159,24 @@ func ModifyAllocationsAction(cCtx *cli.Context, logger iface.Logger) error {
 		return fmt.Errorf("context '%s' not found in configuration", contextName)
 	}
 
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	client, err := ethclient.Dial(l1Cfg.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+	}
+	defer client.Close()
+
+	// Each operator signs its own allocations, so the allocations are sent together and mined in parallel
+	txManager := newContextTxManager(client, envCtx, logger)
+	var reqs []common.TxRequest
+	var allocations []string
 	for _, op := range envCtx.Operators {
+		if !isOperatorSelected(cCtx, op.Address) {
+			continue
+		}
 		logger.Info("Modifying allocations for operator %s", op.Address)
 		if len(op.Allocations) == 0 {
 			logger.Info("Operator %s has no allocations specified, skipping allocation modification", op.Address)
@@ -1027,51 +1188,43 @@ func ModifyAllocationsAction(cCtx *cli.Context, logger iface.Logger) error {
 			logger.Debug("Failed to load ECDSA key for operator %s: %v. Continuing...", op.Address, err)
 			continue
 		}
-		if err := modifyAllocations(cCtx, op.Address, operatorKey, logger); err != nil {
+		opReqs, opAllocations, err := modifyAllocationsTxs(cCtx, cfg, contextName, client, txManager, op.Address, operatorKey, logger)
+		if err != nil {
 			logger.Debug("Failed to modify allocations for operator %s: %v. Continuing...", op.Address, err)
 			continue
 		}
+		reqs = append(reqs, opReqs...)
+		allocations = append(allocations, opAllocations...)
+	}
+
+	results := txManager.SendAll(cCtx.Context, reqs)
+	for i, result := range results {
+		if result.Err != nil {
+			logger.Debug("Failed to modify allocation for %s: %v. Continuing...", allocations[i], result.Err)
+			continue
+		}
+		// Dry runs only plan the allocation
+		if result.Receipt == nil {
+			continue
+		}
+		logger.Info("✅ Successfully modified allocation for %s", allocations[i])
 	}
 	logger.Info("Modifying allocations completed.")
 	return nil
 }
 
-func modifyAllocations(cCtx *cli.Context, operatorAddress string, operatorPrivateKey string, logger iface.Logger) error {
-	// Extract vars
-	contextName := cCtx.String("context")
-
+// modifyAllocationsTxs returns the operator's ModifyAllocations transactions for its allocations in context, together
+// with a description of each allocation. Allocations already at their magnitude have no transaction.
+func modifyAllocationsTxs(cCtx *cli.Context, cfg *common.ConfigWithContextConfig, contextName string, client *ethclient.Client, txManager *common.TxManager, operatorAddress string, operatorPrivateKey string, logger iface.Logger) ([]common.TxRequest, []string, error) {
 	if operatorAddress == "" {
-		return fmt.Errorf("modifyAllocations:operatorAddress parameter is required and cannot be empty")
-	}
-
-	// Load config according to provided contextName
-	var err error
-	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to load configurations: %w", err)
-	}
-
-	// Extract context details
-	envCtx, ok := cfg.Context[contextName]
-	if !ok {
-		return fmt.Errorf("context '%s' not found in configuration", contextName)
+		return nil, nil, fmt.Errorf("modifyAllocations:operatorAddress parameter is required and cannot be empty")
 	}
 
+	envCtx := cfg.Context[contextName]
 	l1Cfg, ok := envCtx.Chains[common.L1]
 	if !ok {
-		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
-	}
-
-	client, err := ethclient.Dial(l1Cfg.RPCURL)
-	if err != nil {
-		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
+		return nil, nil, fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
-	defer client.Close()
 
 	// Find the operator in config
 	var targetOperator *common.OperatorSpec
@@ -1082,21 +1235,43 @@ func modifyAllocations(cCtx *cli.Context, operatorAddress string, operatorPrivat
 		}
 	}
 	if targetOperator == nil {
-		return fmt.Errorf("operator with address %s not found in config", operatorAddress)
+		return nil, nil, fmt.Errorf("operator with address %s not found in config", operatorAddress)
 	}
 
 	if len(targetOperator.Allocations) == 0 {
 		logger.Info("Operator %s has no allocations specified, skipping allocation modification", operatorAddress)
-		return nil
+		return nil, nil, nil
 	}
 
 	// Check deployed operator sets from context
 	deployedOperatorSets := envCtx.OperatorSets
 	if len(deployedOperatorSets) == 0 {
 		logger.Warn("No deployed operator sets found in context, skipping allocation modification")
-		return nil
+		return nil, nil, nil
+	}
+
+	allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
+
+	contractCaller, err := common.NewContractCaller(
+		operatorPrivateKey,
+		big.NewInt(int64(l1Cfg.ChainID)),
+		client,
+		ethcommon.HexToAddress(allocationManagerAddr),
+		ethcommon.HexToAddress(delegationManagerAddr),
+		ethcommon.HexToAddress(strategyManagerAddr),
+		ethcommon.HexToAddress(""),
+		ethcommon.HexToAddress(""),
+		ethcommon.HexToAddress(""),
+		ethcommon.HexToAddress(""),
+		logger,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(txManager)
 
+	var reqs []common.TxRequest
+	var allocations []string
 	// For each allocation in the operator config
 	for _, allocation := range targetOperator.Allocations {
 		strategyAddress := allocation.StrategyAddress
@@ -1106,6 +1281,11 @@ func modifyAllocations(cCtx *cli.Context, operatorAddress string, operatorPrivat
 			operatorSetID := opSetAllocation.OperatorSet
 			allocationInWads := opSetAllocation.AllocationInWads
 
+			// Honor the --operator-set filter
+			if id, err := strconv.ParseUint(operatorSetID, 10, 32); err == nil && !isOperatorSetSelected(cCtx, uint32(id)) {
+				continue
+			}
+
 			// Check if this operator set ID exists in  deployed operator_sets and contains this strategy
 			var strategyFound bool
 			for _, deployedOpSet := range deployedOperatorSets {
@@ -1129,29 +1309,10 @@ func modifyAllocations(cCtx *cli.Context, operatorAddress string, operatorPrivat
 			logger.Info("Modifying allocation for operator %s: operator_set=%s, strategy=%s, allocation=%s",
 				operatorAddress, operatorSetID, strategyAddress, allocationInWads)
 
-			allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
-
-			contractCaller, err := common.NewContractCaller(
-				operatorPrivateKey,
-				big.NewInt(int64(l1Cfg.ChainID)),
-				client,
-				ethcommon.HexToAddress(allocationManagerAddr),
-				ethcommon.HexToAddress(delegationManagerAddr),
-				ethcommon.HexToAddress(strategyManagerAddr),
-				ethcommon.HexToAddress(""),
-				ethcommon.HexToAddress(""),
-				ethcommon.HexToAddress(""),
-				ethcommon.HexToAddress(""),
-				logger,
-			)
-			if err != nil {
-				return fmt.Errorf("failed to create contract caller: %w", err)
-			}
-
 			// Convert operatorSetID string to uint32
 			operatorSetIDUint32, err := strconv.ParseUint(operatorSetID, 10, 32)
 			if err != nil {
-				return fmt.Errorf("failed to parse operator set ID '%s' to uint32: %w", operatorSetID, err)
+				return nil, nil, fmt.Errorf("failed to parse operator set ID '%s' to uint32: %w", operatorSetID, err)
 			}
 
 			// Build strategies array from matched operator set
@@ -1161,10 +1322,10 @@ func modifyAllocations(cCtx *cli.Context, operatorAddress string, operatorPrivat
 			// Parse allocation amount to uint64
 			allocationMagnitude, err := strconv.ParseUint(allocationInWads, 10, 64)
 			if err != nil {
-				return fmt.Errorf("failed to parse allocation amount '%s' to uint64: %w", allocationInWads, err)
+				return nil, nil, fmt.Errorf("failed to parse allocation amount '%s' to uint64: %w", allocationInWads, err)
 			}
 			newMagnitudes := []uint64{allocationMagnitude}
-			err = contractCaller.ModifyAllocations(
+			req, err := contractCaller.ModifyAllocationsTx(
 				cCtx.Context,
 				ethcommon.HexToAddress(operatorAddress),
 				operatorPrivateKey,
@@ -1175,15 +1336,16 @@ func modifyAllocations(cCtx *cli.Context, operatorAddress string, operatorPrivat
 				logger,
 			)
 			if err != nil {
-				return fmt.Errorf("failed to modify allocations: %w", err)
+				return nil, nil, fmt.Errorf("failed to modify allocations: %w", err)
+			}
+			if req != nil {
+				reqs = append(reqs, *req)
+				allocations = append(allocations, fmt.Sprintf("operator %s (operator_set=%s, strategy=%s)", operatorAddress, operatorSetID, strategyAddress))
 			}
-
-			logger.Info("✅ Successfully modified allocation for operator %s (operator_set=%s, strategy=%s)",
-				operatorAddress, operatorSetID, strategyAddress)
 		}
 	}
 
-	return nil
+	return reqs, allocations, nil
 }
 
 func SetAllocationDelayAction(cCtx *cli.Context, logger iface.Logger) error {
@@ -1193,11 +1355,7 @@ func SetAllocationDelayAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Load config according to provided contextName
 	var err error
 	var cfg *common.ConfigWithContextConfig
-	if contextName == "" {
-		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
-	} else {
-		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
-	}
+	cfg, contextName, err = common.LoadConfigWithContextConfigFromContext(cCtx.Context, contextName)
 	if err != nil {
 		return fmt.Errorf("failed to load configurations for set allocation delay: %w", err)
 	}
@@ -1213,16 +1371,21 @@ func SetAllocationDelayAction(cCtx *cli.Context, logger iface.Logger) error {
 		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
 
+	backend, err := devnet.ChainBackendFor(common.L1, l1Cfg)
+	if err != nil {
+		return err
+	}
+
 	client, err := ethclient.Dial(l1Cfg.RPCURL)
 	if err != nil {
 		return fmt.Errorf("failed to connect to L1 RPC: %w", err)
 	}
 	defer client.Close()
 
-	// Instead of mining blocks(because it's infeasible for 126000 blocks(for mainnet) or 30 on sepolia), use anvil_setStorageAt to bypass ALLOCATION_CONFIGURATION_DELAY
+	// Instead of mining blocks(because it's infeasible for 126000 blocks(for mainnet) or 30 on sepolia), write storage to bypass ALLOCATION_CONFIGURATION_DELAY
 	// We need to manipulate the storage that tracks when allocation delays were set for each operator by modifying
 	// the effectBlock field in the AllocationDelayInfo struct.
-	logger.Info("Bypassing allocation configuration delay using anvil_setStorageAt...")
+	logger.Info("Bypassing allocation configuration delay using %s storage writes...", backend.Name())
 
 	allocationManagerAddr, _, _, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
 	currentBlock, err := client.BlockNumber(cCtx.Context)
@@ -1233,6 +1396,9 @@ func SetAllocationDelayAction(cCtx *cli.Context, logger iface.Logger) error {
 	// For each operator, modify their AllocationDelayInfo struct
 	// Ref https://github.com/Layr-Labs/eigenlayer-contracts/blob/c08c9e849c27910f36f3ab746f3663a18838067f/src/contracts/core/AllocationManagerStorage.sol#L63
 	for _, op := range envCtx.Operators {
+		if !isOperatorSelected(cCtx, op.Address) {
+			continue
+		}
 		operatorAddr := ethcommon.HexToAddress(op.Address)
 
 		// Calculate storage slot for _allocationDelayInfo mapping
@@ -1274,11 +1440,7 @@ func SetAllocationDelayAction(cCtx *cli.Context, logger iface.Logger) error {
 		offset -= 4
 		binary.BigEndian.PutUint32(structValue[offset:], effectBlock)
 
-		var setStorageResult interface{}
-		err = rpcClient.Call(&setStorageResult, "anvil_setStorageAt",
-			allocationManagerAddr,
-			storageKey.Hex(),
-			hex.EncodeToString(structValue))
+		err = backend.SetStorageAt(cCtx.Context, rpcClient, ethcommon.HexToAddress(allocationManagerAddr), storageKey, ethcommon.BytesToHash(structValue))
 		if err != nil {
 			logger.Warn("Failed to manipulate AllocationDelayInfo storage for operator %s: %v", op.Address, err)
 		} else {
@@ -1291,6 +1453,194 @@ func SetAllocationDelayAction(cCtx *cli.Context, logger iface.Logger) error {
 	return nil
 }
 
+// FundDevnetWalletsAction funds the operator and transporter wallets defined in config on both L1 and L2
+func FundDevnetWalletsAction(cCtx *cli.Context, logger iface.Logger) error {
+	// Load config according to provided contextName
+	contextName := cCtx.String("context")
+	var err error
+	var cfg *common.ConfigWithContextConfig
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configurations for funding wallets: %w", err)
+	}
+
+	// Extract context details
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+
+	// Fund the wallets defined in config on L1 and then every L2, a broken wallet entry is reported without
+	// stopping the remaining wallets from being funded
+	report := &devnet.FundingReport{}
+	for _, chainName := range append([]string{common.L1}, common.L2ChainNames(envCtx.Chains)...) {
+		logger.Info("Funding wallets on %s...", strings.ToUpper(chainName))
+		chainReport, err := devnet.FundWalletsDevnet(cfg, chainName, envCtx.Chains[chainName].RPCURL)
+		if err != nil {
+			return fmt.Errorf("funding %s devnet wallets failed: %w", strings.ToUpper(chainName), err)
+		}
+		report.Merge(chainReport)
+	}
+
+	if err := report.WriteSummary(cCtx.App.Writer); err != nil {
+		return err
+	}
+	if err := report.Err(); err != nil {
+		return fmt.Errorf("funding devnet wallets failed: %w", err)
+	}
+	return nil
+}
+
+// FundDevnetAccountsAction tops up the ETH and ERC20 balances declared under the context's funding section
+func FundDevnetAccountsAction(cCtx *cli.Context, logger iface.Logger) error {
+	if os.Getenv("SKIP_DEVNET_FUNDING") == "true" {
+		logger.Info("🔧 Skipping devnet account funding (test mode)")
+		return nil
+	}
+
+	// Load config according to provided contextName
+	contextName := cCtx.String("context")
+	var err error
+	var cfg *common.ConfigWithContextConfig
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configurations for funding accounts: %w", err)
+	}
+
+	// Extract context details
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	if len(envCtx.Funding) == 0 {
+		logger.Info("No funding declared in context, skipping account funding.")
+		return nil
+	}
+
+	// Check every referenced chain exists before funding anything
+	for _, account := range envCtx.Funding {
+		for _, chainName := range fundingChains(account) {
+			if _, ok := envCtx.Chains[chainName]; !ok {
+				return fmt.Errorf("funding for %s references unknown chain %q", account.Address, chainName)
+			}
+		}
+	}
+
+	report := &devnet.FundingReport{}
+	funders := map[string]*devnet.Funder{}
+	var clients []*rpc.Client
+	defer func() {
+		for _, client := range clients {
+			client.Close()
+		}
+	}()
+
+	for _, account := range envCtx.Funding {
+		for _, chainName := range fundingChains(account) {
+			funder, ok := funders[chainName]
+			if !ok {
+				client, err := rpc.DialContext(cCtx.Context, envCtx.Chains[chainName].RPCURL)
+				if err != nil {
+					return fmt.Errorf("failed to connect to %s: %w", chainName, err)
+				}
+				clients = append(clients, client)
+				backend, err := devnet.ChainBackendFor(chainName, envCtx.Chains[chainName])
+				if err != nil {
+					return err
+				}
+				if funder, err = devnet.NewFunder(chainName, backend, client, logger); err != nil {
+					return err
+				}
+				funders[chainName] = funder
+			}
+
+			logger.Info("Funding %s on %s...", account.Address, chainName)
+			funder.FundAccount(cCtx.Context, account, report)
+		}
+	}
+
+	if err := report.WriteSummary(cCtx.App.Writer); err != nil {
+		return err
+	}
+	if err := report.Err(); err != nil {
+		return fmt.Errorf("funding accounts failed: %w", err)
+	}
+	return nil
+}
+
+// fundingChains returns the chains a funding entry applies to, defaulting to the l1
+func fundingChains(account common.FundingAccount) []string {
+	if len(account.Chains) == 0 {
+		return []string{common.L1}
+	}
+	return account.Chains
+}
+
+// FundStakersWithStrategyTokensAction funds the stakers defined in config with the underlying tokens of their strategies
+func FundStakersWithStrategyTokensAction(cCtx *cli.Context, logger iface.Logger) error {
+	// Load config according to provided contextName
+	contextName := cCtx.String("context")
+	var err error
+	var cfg *common.ConfigWithContextConfig
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configurations for funding stakers: %w", err)
+	}
+
+	// Token funding relies on whale impersonation which is only possible on devnet
+	if contextName != devnet.DEVNET_CONTEXT {
+		logger.Info("Skipping token funding for non-devnet context")
+		return nil
+	}
+
+	// Extract context details
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	l1RpcUrl := envCtx.Chains[common.L1].RPCURL
+
+	logger.Info("Funding stakers with strategy tokens...")
+	tokenAddresses, err := devnet.GetUnderlyingTokenAddressesFromStrategies(cfg, l1RpcUrl, logger)
+	if err != nil {
+		logger.Warn("Failed to get underlying token addresses from strategies: %v", err)
+		logger.Info("Continuing with devnet startup...")
+	}
+
+	if len(tokenAddresses) == 0 {
+		logger.Info("No tokens to fund stakers with, skipping token funding")
+		return nil
+	}
+
+	report, err := devnet.FundStakersWithStrategyTokens(cfg, l1RpcUrl, tokenAddresses, logger)
+	if err != nil {
+		logger.Warn("Failed to fund stakers with strategy tokens: %v", err)
+		logger.Info("Continuing with devnet startup...")
+		return nil
+	}
+	if err := report.WriteSummary(cCtx.App.Writer); err != nil {
+		return err
+	}
+	if err := report.Err(); err != nil {
+		logger.Warn("Failed to fund some stakers with strategy tokens: %v", err)
+		logger.Info("Continuing with devnet startup...")
+	}
+
+	return nil
+}
+
 func WhitelistChainIdInCrossRegistryAction(cCtx *cli.Context, logger iface.Logger) error {
 	// Extract vars
 	contextName := cCtx.String("context")
@@ -1324,8 +1674,8 @@ func WhitelistChainIdInCrossRegistryAction(cCtx *cli.Context, logger iface.Logge
 		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
 	}
 
-	l2Cfg, ok := envCtx.Chains[common.L2]
-	if !ok {
+	l2Names := common.L2ChainNames(envCtx.Chains)
+	if len(l2Names) == 0 {
 		return fmt.Errorf("failed to get l2 chain config for context '%s'", contextName)
 	}
 
@@ -1357,19 +1707,23 @@ func WhitelistChainIdInCrossRegistryAction(cCtx *cli.Context, logger iface.Logge
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 	// whitelist l1 chain id in cross registry
 	err = contractCaller.WhitelistChainIdInCrossRegistry(cCtx.Context, l1OperatorTableUpdater, uint64(l1Cfg.ChainID))
 	if err != nil {
 		return fmt.Errorf("failed to whitelist l1 ChainId in CrossChainRegistry: %w", err)
 	}
 
-	// whitelist l2 chain id in cross registry
-	err = contractCaller.WhitelistChainIdInCrossRegistry(cCtx.Context, l2OperatorTableUpdater, uint64(l2Cfg.ChainID))
-	if err != nil {
-		return fmt.Errorf("failed to whitelist l2 ChainId in CrossChainRegistry: %w", err)
+	// whitelist every l2 chain id in cross registry, the multichain contracts share their addresses across L2s
+	for _, chainName := range l2Names {
+		l2Cfg := envCtx.Chains[chainName]
+		err = contractCaller.WhitelistChainIdInCrossRegistry(cCtx.Context, l2OperatorTableUpdater, uint64(l2Cfg.ChainID))
+		if err != nil {
+			return fmt.Errorf("failed to whitelist %s ChainId in CrossChainRegistry: %w", chainName, err)
+		}
 	}
 
-	logger.Info("Successfully whitelisted l1 chain id in cross registry")
+	logger.Info("Successfully whitelisted l1 and %d l2 chain id(s) in cross registry", len(l2Names))
 	return nil
 }
 
@@ -1414,6 +1768,91 @@ func stopContainerByPort(cCtx *cli.Context, log iface.Logger, targetPort int, co
 	}
 }
 
+// stopProjectContainers takes down every devnet labelled with the project and, unless contextName is empty, the
+// context, along with its network and volumes. Falls back to the l1/l2 container names for containers started
+// before the labels were added, and to removing containers one by one for devnets without a compose file.
+func stopProjectContainers(cCtx *cli.Context, log iface.Logger, projectName, contextName string) {
+	containerNames, err := devnet.ProjectContainerNames(cCtx.Context, projectName, contextName)
+	if err != nil {
+		log.Warn("Failed to list devnet containers for project %s: %v", projectName, err)
+	}
+	if len(containerNames) == 0 {
+		containerNames = []string{devnet.ChainContainerName(common.L1, projectName), devnet.ChainContainerName(common.L2, projectName)}
+	}
+
+	// Take each devnet down through compose so its network and volumes are removed with the containers
+	takenDown := map[string]bool{}
+	for _, containerName := range containerNames {
+		containerContext := contextName
+		if containerContext == "" {
+			_, containerContext, _ = devnet.ContainerProject(cCtx.Context, containerName)
+		}
+		namespace := devnet.Namespace(projectName, containerContext)
+		down, seen := takenDown[namespace]
+		if !seen {
+			down = devnet.ComposeDown(cCtx, namespace)
+			takenDown[namespace] = down
+		}
+		if !down {
+			devnet.StopAndRemoveContainer(cCtx, containerName)
+		}
+	}
+}
+
+// stopRecordedDevnet stops the project's devnet for the context and forgets its recorded state. Containers which
+// predate the context label are found through the l1 port recorded when the devnet was started.
+func stopRecordedDevnet(cCtx *cli.Context, log iface.Logger, projectName, contextName string) error {
+	state, err := devnet.LoadState(contextName)
+	if err != nil {
+		return err
+	}
+
+	labelled, err := devnet.ProjectContainerNames(cCtx.Context, projectName, contextName)
+	if err != nil {
+		log.Warn("Failed to list devnet containers for project %s: %v", projectName, err)
+	}
+	if len(labelled) == 0 && state.L1Port != 0 {
+		if !devnet.ComposeDown(cCtx, devnet.Namespace(projectName, contextName)) {
+			stopBothContainersByPort(cCtx, log, state.L1Port)
+		}
+	} else {
+		stopProjectContainers(cCtx, log, projectName, contextName)
+	}
+
+	if ports := state.ChainPorts(); len(ports) > 0 {
+		log.Info("Stopped devnet %s, released ports %s", devnet.Namespace(projectName, contextName), formatChainPorts(ports))
+	}
+	return devnet.RemoveState(contextName)
+}
+
+// formatChainPorts renders chain ports as "l1:8545 l2:9545 ..." with the l1 and l2 first
+func formatChainPorts(ports map[string]int) string {
+	names := make([]string, 0, len(ports))
+	for name := range ports {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if rank := map[string]int{common.L1: -2, common.L2: -1}; rank[names[i]] != rank[names[j]] {
+			return rank[names[i]] < rank[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s:%d", name, ports[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeForkBlock renders a fork block for logs, 0 being the latest block
+func describeForkBlock(block int) string {
+	if block == 0 {
+		return "the latest block"
+	}
+	return fmt.Sprintf("block %d", block)
+}
+
 // stopBothContainersByPort stops both L1 and L2 containers for the project found on the given port
 func stopBothContainersByPort(cCtx *cli.Context, log iface.Logger, targetPort int) {
 	cmd := exec.CommandContext(cCtx.Context, "docker", devnet.GetDockerPsDevnetArgs()...)
@@ -1425,7 +1864,7 @@ func stopBothContainersByPort(cCtx *cli.Context, log iface.Logger, targetPort in
 
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
 	containerFound := false
-	projectsToStop := make(map[string]bool) // Track projects we've already stopped
+	projectsToStop := make(map[string]bool) // Track devnets we've already stopped
 
 	for _, line := range lines {
 		parts := strings.Split(line, ": ")
@@ -1437,27 +1876,26 @@ func stopBothContainersByPort(cCtx *cli.Context, log iface.Logger, targetPort in
 		hostPort := extractHostPort(port)
 
 		if hostPort == fmt.Sprintf("%d", targetPort) {
-			// Extract project name from container name
-			var projectName string
-			if strings.HasPrefix(containerName, "devkit-devnet-l1-") {
-				projectName = strings.TrimPrefix(containerName, "devkit-devnet-l1-")
-			} else if strings.HasPrefix(containerName, "devkit-devnet-l2-") {
-				projectName = strings.TrimPrefix(containerName, "devkit-devnet-l2-")
-			} else {
-				// Fallback for old naming convention
-				projectName = strings.TrimPrefix(containerName, "devkit-devnet-")
+			// Read the project and context from the container labels, else extract the project from the container name
+			projectName, contextName, _ := devnet.ContainerProject(cCtx.Context, containerName)
+			if projectName == "" {
+				if strings.HasPrefix(containerName, "devkit-devnet-l1-") {
+					projectName = strings.TrimPrefix(containerName, "devkit-devnet-l1-")
+				} else if strings.HasPrefix(containerName, "devkit-devnet-l2-") {
+					projectName = strings.TrimPrefix(containerName, "devkit-devnet-l2-")
+				} else {
+					// Fallback for old naming convention
+					projectName = strings.TrimPrefix(containerName, "devkit-devnet-")
+				}
 			}
 
-			// If we haven't stopped this project yet, stop both L1 and L2 containers
-			if !projectsToStop[projectName] {
-				l1Container := fmt.Sprintf("devkit-devnet-l1-%s", projectName)
-				l2Container := fmt.Sprintf("devkit-devnet-l2-%s", projectName)
-
-				devnet.StopAndRemoveContainer(cCtx, l1Container)
-				devnet.StopAndRemoveContainer(cCtx, l2Container)
+			// If we haven't stopped this devnet yet, stop the L1 and every L2 container
+			key := projectName + "/" + contextName
+			if !projectsToStop[key] {
+				stopProjectContainers(cCtx, log, projectName, contextName)
 
-				log.Info("Stopped both L1 and L2 devnet containers for project %s (found port %d)", projectName, targetPort)
-				projectsToStop[projectName] = true
+				log.Info("Stopped the devnet containers for project %s (found port %d)", projectName, targetPort)
+				projectsToStop[key] = true
 				containerFound = true
 			}
 		}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli/v2"
+)
+
+// BootstrapEigenLayerAction deploys the EigenLayer core contracts onto non-forked devnet chains and records their addresses in context.eigenlayer
+func BootstrapEigenLayerAction(cCtx *cli.Context, logger iface.Logger) error {
+	// Load config according to provided contextName
+	contextName := cCtx.String("context")
+	var err error
+	var cfg *common.ConfigWithContextConfig
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configurations for bootstrapping eigenlayer: %w", err)
+	}
+	if contextName != devnet.DEVNET_CONTEXT {
+		return fmt.Errorf("bootstrapping eigenlayer is only available on devnet - please run with `--context devnet`")
+	}
+
+	// Extract context details
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	l1Cfg, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return fmt.Errorf("failed to get l1 chain config for context '%s'", contextName)
+	}
+	l2Names := common.L2ChainNames(envCtx.Chains)
+	if len(l2Names) == 0 {
+		return fmt.Errorf("failed to get l2 chain config for context '%s'", contextName)
+	}
+
+	// Collect the strategies referenced by stakers and operators, and the stakers to fund
+	seen := map[ethcommon.Address]bool{}
+	var strategies []ethcommon.Address
+	addStrategy := func(addr string) {
+		strategy := ethcommon.HexToAddress(addr)
+		if !seen[strategy] {
+			seen[strategy] = true
+			strategies = append(strategies, strategy)
+		}
+	}
+	var recipients []ethcommon.Address
+	for _, staker := range envCtx.Stakers {
+		recipients = append(recipients, ethcommon.HexToAddress(staker.StakerAddress))
+		for _, deposit := range staker.Deposits {
+			addStrategy(deposit.StrategyAddress)
+		}
+	}
+	for _, operator := range envCtx.Operators {
+		for _, allocation := range operator.Allocations {
+			addStrategy(allocation.StrategyAddress)
+		}
+	}
+
+	deployerKey, err := crypto.HexToECDSA(devnet.ANVIL_1_KEY[2:])
+	if err != nil {
+		return fmt.Errorf("invalid deployer key: %w", err)
+	}
+
+	l1Backend, err := devnet.ChainBackendFor(common.L1, l1Cfg)
+	if err != nil {
+		return err
+	}
+	l1, closeL1, err := devnet.DialBootstrapChain(cCtx.Context, "L1", l1Backend, l1Cfg.RPCURL)
+	if err != nil {
+		return err
+	}
+	defer closeL1()
+	l2s := make([]*devnet.BootstrapChain, 0, len(l2Names))
+	for _, name := range l2Names {
+		l2Backend, err := devnet.ChainBackendFor(name, envCtx.Chains[name])
+		if err != nil {
+			return err
+		}
+		l2, closeL2, err := devnet.DialBootstrapChain(cCtx.Context, strings.ToUpper(name), l2Backend, envCtx.Chains[name].RPCURL)
+		if err != nil {
+			return err
+		}
+		defer closeL2()
+		l2s = append(l2s, l2)
+	}
+
+	logger.Title("Bootstrapping EigenLayer core contracts...")
+	deployment, err := devnet.BootstrapEigenLayer(cCtx.Context, l1, l2s, devnet.BootstrapConfig{
+		DeployerKey:             deployerKey,
+		CrossChainRegistryOwner: ethcommon.HexToAddress(common.CrossChainRegistryOwnerAddress),
+		Strategies:              strategies,
+		TokenRecipients:         recipients,
+	}, logger)
+	if err != nil {
+		return err
+	}
+
+	// Record the deployed addresses in context
+	yamlPath, rootNode, contextNode, _, err := common.LoadContext(contextName)
+	if err != nil {
+		return fmt.Errorf("loading context nodes failed: %w", err)
+	}
+	addresses := []struct {
+		path    []string
+		address ethcommon.Address
+	}{
+		{[]string{"eigenlayer", "l1", "allocation_manager"}, deployment.AllocationManager},
+		{[]string{"eigenlayer", "l1", "delegation_manager"}, deployment.DelegationManager},
+		{[]string{"eigenlayer", "l1", "strategy_manager"}, deployment.StrategyManager},
+		{[]string{"eigenlayer", "l1", "cross_chain_registry"}, deployment.CrossChainRegistry},
+		{[]string{"eigenlayer", "l1", "key_registrar"}, deployment.KeyRegistrar},
+		{[]string{"eigenlayer", "l1", "release_manager"}, deployment.ReleaseManager},
+		{[]string{"eigenlayer", "l1", "operator_table_updater"}, deployment.L1.OperatorTableUpdater},
+		{[]string{"eigenlayer", "l1", "task_mailbox"}, deployment.L1.TaskMailbox},
+		{[]string{"eigenlayer", "l2", "bn254_certificate_verifier"}, deployment.L2.BN254CertificateVerifier},
+		{[]string{"eigenlayer", "l2", "ecdsa_certificate_verifier"}, deployment.L2.ECDSACertificateVerifier},
+		{[]string{"eigenlayer", "l2", "operator_table_updater"}, deployment.L2.OperatorTableUpdater},
+		{[]string{"eigenlayer", "l2", "task_mailbox"}, deployment.L2.TaskMailbox},
+	}
+	for _, entry := range addresses {
+		if _, err := common.WriteToPath(contextNode, entry.path, entry.address.Hex()); err != nil {
+			return fmt.Errorf("failed to write %s to context: %w", entry.path[len(entry.path)-1], err)
+		}
+	}
+	if err := common.WriteYAML(yamlPath, rootNode); err != nil {
+		return fmt.Errorf("failed to save bootstrapped addresses to context: %w", err)
+	}
+
+	for strategy, token := range deployment.StrategyTokens {
+		logger.Info("Strategy %s backed by mock token %s", strategy.Hex(), token.Hex())
+	}
+	logger.Info("EigenLayer core contracts deployed, addresses written to %s", yamlPath)
+	return nil
+}
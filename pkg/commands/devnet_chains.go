@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+)
+
+// devnetChain is a chain run by the devnet along with the host port it is exposed on
+type devnetChain struct {
+	Name          string
+	Port          int
+	ContainerName string
+	Config        common.ChainConfig
+	// Backend is the node the chain runs on
+	Backend devnet.ChainBackend
+}
+
+// isL1 reports whether the chain is the devnet's L1
+func (c devnetChain) isL1() bool {
+	return c.Name == common.L1
+}
+
+// resolveDevnetChains returns the context's l1 followed by each of its L2s (see common.L2ChainNames), with containers
+// named for the devnet namespace. The l1 and primary l2 are exposed on l1Port and l2Port, any further L2 on the port
+// recorded in l2Ports, else its configured port, else the next free port after l2Port.
+func resolveDevnetChains(envCtx common.ChainContextConfig, namespace string, l1Port, l2Port int, l2Ports map[string]int) ([]devnetChain, error) {
+	l1Config, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return nil, fmt.Errorf("failed to find a chain with name: l1 in devnet.yaml")
+	}
+	if _, ok := envCtx.Chains[common.L2]; !ok {
+		return nil, fmt.Errorf("failed to find a chain with name: l2 in devnet.yaml")
+	}
+
+	l1Backend, err := devnet.ChainBackendFor(common.L1, l1Config)
+	if err != nil {
+		return nil, err
+	}
+
+	chains := []devnetChain{{Name: common.L1, Port: l1Port, ContainerName: devnet.ChainContainerName(common.L1, namespace), Config: l1Config, Backend: l1Backend}}
+	usedPorts := map[int]string{l1Port: common.L1}
+	chainIds := map[int]string{}
+	if l1Config.ChainID != 0 {
+		chainIds[l1Config.ChainID] = common.L1
+	}
+
+	nextPort := l2Port
+	for _, name := range common.L2ChainNames(envCtx.Chains) {
+		chainConfig := envCtx.Chains[name]
+		if name != common.L2 && chainConfig.ChainID == 0 {
+			return nil, fmt.Errorf("chain_id not set for %s; every additional L2 needs its own chain_id in ./config/context/devnet.yaml", name)
+		}
+		backend, err := devnet.ChainBackendFor(name, chainConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		port := l2Port
+		if name != common.L2 {
+			switch {
+			case l2Ports[name] != 0:
+				port = l2Ports[name]
+			case chainConfig.Port != 0:
+				port = chainConfig.Port
+			default:
+				nextPort++
+				for usedPorts[nextPort] != "" || isConfiguredPort(envCtx, nextPort) {
+					nextPort++
+				}
+				port = nextPort
+			}
+		}
+		if other, taken := usedPorts[port]; taken {
+			return nil, fmt.Errorf("chains %s and %s are both configured to use port %d", other, name, port)
+		}
+		usedPorts[port] = name
+
+		if chainConfig.ChainID != 0 {
+			if other, taken := chainIds[chainConfig.ChainID]; taken {
+				return nil, fmt.Errorf("chains %s and %s share chain_id %d, each chain needs its own chain_id", other, name, chainConfig.ChainID)
+			}
+			chainIds[chainConfig.ChainID] = name
+		}
+
+		chains = append(chains, devnetChain{Name: name, Port: port, ContainerName: devnet.ChainContainerName(name, namespace), Config: chainConfig, Backend: backend})
+	}
+	return chains, nil
+}
+
+// allocateDevnetPorts picks a free host port for the l1 and every L2 of the context, for `devnet start --auto-ports`.
+// The ports are returned in the form taken by resolveDevnetChains.
+func allocateDevnetPorts(envCtx common.ChainContextConfig) (l1Port, l2Port int, l2Ports map[string]int, err error) {
+	names := common.L2ChainNames(envCtx.Chains)
+	ports, err := devnet.FreePorts(len(names) + 1)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	l1Port = ports[0]
+	l2Ports = map[string]int{}
+	for i, name := range names {
+		if name == common.L2 {
+			l2Port = ports[i+1]
+		} else {
+			l2Ports[name] = ports[i+1]
+		}
+	}
+	return l1Port, l2Port, l2Ports, nil
+}
+
+// applyRecordedRPCURLs points the context's chains at the ports recorded by `devnet start`, so that commands reach
+// the devnet even when it was started with --auto-ports or custom ports
+func applyRecordedRPCURLs(envCtx common.ChainContextConfig, contextName string) error {
+	if contextName != devnet.DEVNET_CONTEXT {
+		return nil
+	}
+	state, err := devnet.LoadState(contextName)
+	if err != nil {
+		return err
+	}
+	state.ApplyRPCURLs(envCtx.Chains)
+	return nil
+}
+
+// devnetForkedChainIDs returns the chain ids of the public chains forked by the devnet of the context, none when it
+// was started with --no-fork, see common.ForkedChainIDs
+func devnetForkedChainIDs(ctx context.Context, cfg *common.ConfigWithContextConfig, contextName string) ([]uint64, error) {
+	state, err := devnet.LoadState(contextName)
+	if err != nil {
+		return nil, err
+	}
+	if state.NoFork {
+		return nil, nil
+	}
+	return common.ForkedChainIDs(ctx, contextName, cfg)
+}
+
+// checkDevnetPortsAvailable errors if the port of any chain, or a host port published by an extra service, is already in use
+func checkDevnetPortsAvailable(chains []devnetChain, services []common.ServiceConfig) error {
+	for _, svc := range services {
+		for _, port := range devnet.ServiceHostPorts(svc) {
+			if !devnet.IsPortAvailable(port) {
+				return fmt.Errorf("❌ Port %d published by service %s is already in use. Please change services.%s.ports", port, svc.Name, svc.Name)
+			}
+		}
+	}
+
+	for _, chain := range chains {
+		if devnet.IsPortAvailable(chain.Port) {
+			continue
+		}
+		switch chain.Name {
+		case common.L1:
+			return fmt.Errorf("❌ Port %d is already in use. Please choose a different port using --l1-port", chain.Port)
+		case common.L2:
+			return fmt.Errorf("❌ L2 port %d is already in use. Please choose a different port using --l2-port", chain.Port)
+		default:
+			return fmt.Errorf("❌ %s port %d is already in use. Please set a different port in chains.%s.port", chain.Name, chain.Port, chain.Name)
+		}
+	}
+	return nil
+}
+
+// isConfiguredPort reports whether any chain in the context explicitly requests port
+func isConfiguredPort(envCtx common.ChainContextConfig, port int) bool {
+	for _, chainConfig := range envCtx.Chains {
+		if chainConfig.Port == port {
+			return true
+		}
+	}
+	return false
+}
+
+// extraL2Ports returns the ports of the L2s beyond the primary l2, as recorded in the devnet state
+func extraL2Ports(chains []devnetChain) map[string]int {
+	ports := map[string]int{}
+	for _, chain := range chains {
+		if !chain.isL1() && chain.Name != common.L2 {
+			ports[chain.Name] = chain.Port
+		}
+	}
+	return ports
+}
+
+// devnetRPCURLs maps each chain name to the devnet RPC url it is served on
+func devnetRPCURLs(chains []devnetChain) map[string]string {
+	urls := make(map[string]string, len(chains))
+	for _, chain := range chains {
+		urls[chain.Name] = devnet.GetRPCURL(chain.Port)
+	}
+	return urls
+}
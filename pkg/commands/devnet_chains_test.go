@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDevnetChains(t *testing.T) {
+	envCtx := common.ChainContextConfig{
+		Chains: map[string]common.ChainConfig{
+			common.L1: {ChainID: 31337},
+			common.L2: {ChainID: 31338},
+			"l2-op":   {ChainID: 31339},
+			"l2-base": {ChainID: 31340, Port: 9546},
+			"l2-zk":   {ChainID: 31341},
+		},
+	}
+
+	chains, err := resolveDevnetChains(envCtx, "proj", 8545, 9545, map[string]int{"l2-zk": 9600})
+	require.NoError(t, err)
+
+	ports := map[string]int{}
+	for _, chain := range chains {
+		ports[chain.Name] = chain.Port
+	}
+	assert.Equal(t, common.L1, chains[0].Name)
+	assert.Equal(t, common.L2, chains[1].Name)
+	// Configured and recorded ports are kept, the rest are allocated after the l2 port skipping taken ones
+	assert.Equal(t, map[string]int{common.L1: 8545, common.L2: 9545, "l2-base": 9546, "l2-op": 9547, "l2-zk": 9600}, ports)
+	assert.Equal(t, "devkit-devnet-l2-op-proj", chains[3].ContainerName)
+	assert.Equal(t, map[string]int{"l2-base": 9546, "l2-op": 9547, "l2-zk": 9600}, extraL2Ports(chains))
+}
+
+func TestResolveDevnetChainsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		chains map[string]common.ChainConfig
+		errMsg string
+	}{
+		{"missing l2", map[string]common.ChainConfig{common.L1: {ChainID: 31337}}, "name: l2"},
+		{"missing chain id", map[string]common.ChainConfig{common.L1: {ChainID: 31337}, common.L2: {ChainID: 31338}, "l2-op": {}}, "chain_id not set for l2-op"},
+		{"duplicate chain id", map[string]common.ChainConfig{common.L1: {ChainID: 31337}, common.L2: {ChainID: 31338}, "l2-op": {ChainID: 31338}}, "share chain_id 31338"},
+		{"duplicate port", map[string]common.ChainConfig{common.L1: {ChainID: 31337}, common.L2: {ChainID: 31338}, "l2-op": {ChainID: 31339, Port: 8545}}, "port 8545"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveDevnetChains(common.ChainContextConfig{Chains: tt.chains}, "proj", 8545, 9545, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestAllocateDevnetPorts(t *testing.T) {
+	envCtx := common.ChainContextConfig{
+		Chains: map[string]common.ChainConfig{
+			common.L1: {ChainID: 31337},
+			common.L2: {ChainID: 31338},
+			"l2-op":   {ChainID: 31339, Port: 9546},
+		},
+	}
+
+	l1Port, l2Port, l2Ports, err := allocateDevnetPorts(envCtx)
+	require.NoError(t, err)
+
+	// Every chain gets its own free port, overriding configured ones
+	chains, err := resolveDevnetChains(envCtx, devnet.Namespace("proj", "devnet"), l1Port, l2Port, l2Ports)
+	require.NoError(t, err)
+	seen := map[int]bool{}
+	for _, chain := range chains {
+		assert.NotZero(t, chain.Port)
+		assert.NotEqual(t, 9546, chain.Port)
+		assert.False(t, seen[chain.Port], "port %d allocated twice", chain.Port)
+		seen[chain.Port] = true
+	}
+	assert.Equal(t, "devkit-devnet-l2-op-proj-devnet", chains[2].ContainerName)
+}
+
+func TestFormatChainPorts(t *testing.T) {
+	assert.Equal(t, "l1:8545 l2:9545 l2-base:9546 l2-op:9547", formatChainPorts(map[string]int{"l2-op": 9547, common.L2: 9545, "l2-base": 9546, common.L1: 8545}))
+}
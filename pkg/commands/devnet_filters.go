@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// operatorFilterFlag restricts a setup step to the given operator addresses
+var operatorFilterFlag = &cli.StringSliceFlag{
+	Name:  "operator",
+	Usage: "Only apply the step to the given operator address (repeatable)",
+}
+
+// operatorSetFilterFlag restricts a setup step to the given operator set ids
+var operatorSetFilterFlag = &cli.IntSliceFlag{
+	Name:  "operator-set",
+	Usage: "Only apply the step to the given operator set id (repeatable)",
+}
+
+// isOperatorSelected reports whether the operator passes the --operator filter (no filter selects everything)
+func isOperatorSelected(cCtx *cli.Context, address string) bool {
+	selected := cCtx.StringSlice(operatorFilterFlag.Name)
+	if len(selected) == 0 {
+		return true
+	}
+	for _, s := range selected {
+		if strings.EqualFold(strings.TrimSpace(s), address) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOperatorSetSelected reports whether the operator set passes the --operator-set filter (no filter selects everything)
+func isOperatorSetSelected(cCtx *cli.Context, operatorSetID uint32) bool {
+	selected := cCtx.IntSlice(operatorSetFilterFlag.Name)
+	if len(selected) == 0 {
+		return true
+	}
+	for _, id := range selected {
+		if id >= 0 && uint32(id) == operatorSetID {
+			return true
+		}
+	}
+	return false
+}
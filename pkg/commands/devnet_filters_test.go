@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestOperatorFilters(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		operator      string
+		operatorSetID uint32
+		wantOperator  bool
+		wantSet       bool
+	}{
+		{
+			name:          "no filters selects everything",
+			args:          []string{},
+			operator:      "0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+			operatorSetID: 1,
+			wantOperator:  true,
+			wantSet:       true,
+		},
+		{
+			name:          "operator filter is case insensitive",
+			args:          []string{"--operator", "0x90f79bf6eb2c4f870365e785982e1f101e93b906"},
+			operator:      "0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+			operatorSetID: 1,
+			wantOperator:  true,
+			wantSet:       true,
+		},
+		{
+			name:          "operator filter excludes others",
+			args:          []string{"--operator", "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"},
+			operator:      "0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+			operatorSetID: 1,
+			wantOperator:  false,
+			wantSet:       true,
+		},
+		{
+			name:          "operator set filter",
+			args:          []string{"--operator-set", "0", "--operator-set", "2"},
+			operator:      "0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+			operatorSetID: 1,
+			wantOperator:  true,
+			wantSet:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOperator, gotSet bool
+			app, _ := testutils.CreateTestAppWithNoopLoggerAndAccess("devkit", []cli.Flag{operatorFilterFlag, operatorSetFilterFlag}, func(cCtx *cli.Context) error {
+				gotOperator = isOperatorSelected(cCtx, tt.operator)
+				gotSet = isOperatorSetSelected(cCtx, tt.operatorSetID)
+				return nil
+			})
+
+			require.NoError(t, app.Run(append([]string{"devkit"}, tt.args...)))
+			assert.Equal(t, tt.wantOperator, gotOperator)
+			assert.Equal(t, tt.wantSet, gotSet)
+		})
+	}
+}
+
+func TestSetupStepCommandsAreRegistered(t *testing.T) {
+	registered := map[string]int{}
+	for _, cmd := range DevnetCommand.Subcommands {
+		registered[cmd.Name]++
+	}
+
+	for _, cmd := range setupStepCommands() {
+		assert.Equal(t, 1, registered[cmd.Name], "step command %s should be registered exactly once", cmd.Name)
+		assert.NotEmpty(t, cmd.Usage, "step command %s should have usage", cmd.Name)
+		assert.NotNil(t, cmd.Action)
+
+		var hasContext bool
+		for _, f := range cmd.Flags {
+			if f.Names()[0] == "context" {
+				hasContext = true
+			}
+		}
+		assert.True(t, hasContext, "step command %s should accept --context", cmd.Name)
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// pinDevnetForkBlocks resolves a --fork-block value and writes the blocks into the context's fork settings.
+// The latest and finalized tags are resolved on every forked chain, a block number pins the l1.
+func pinDevnetForkBlocks(cCtx *cli.Context, logger iface.Logger, config *common.ConfigWithContextConfig, contextName string, contextNode *yaml.Node, spec string) error {
+	block, numbered, err := devnet.ParseForkBlock(spec)
+	if err != nil {
+		return err
+	}
+
+	names := []string{common.L1}
+	if !numbered {
+		names = append(names, common.L2ChainNames(config.Context[contextName].Chains)...)
+	}
+	for _, name := range names {
+		if !numbered {
+			forkUrl, err := common.GetForkUrlDefault(contextName, config, name)
+			if err != nil {
+				return fmt.Errorf("%s fork URL error: %w", name, err)
+			}
+			if block, err = devnet.ResolveForkBlock(cCtx.Context, forkUrl, spec); err != nil {
+				return fmt.Errorf("failed to resolve %s fork block: %w", name, err)
+			}
+		}
+
+		if _, err := common.WriteToPath(contextNode, []string{"chains", name, "fork", "block"}, strconv.FormatUint(block, 10)); err != nil {
+			return fmt.Errorf("failed to set %s fork block: %w", name, err)
+		}
+		logger.Info("Pinned the %s fork to block %d", name, block)
+	}
+	return nil
+}
+
+// checkDevnetForks verifies, before any container starts, that each chain's fork provider serves state at the fork
+// block and that the EigenLayer contracts configured under eigenlayer.l1 are deployed there
+func checkDevnetForks(cCtx *cli.Context, logger iface.Logger, config *common.ConfigWithContextConfig, contextName string, chains []devnetChain) error {
+	envCtx := config.Context[contextName]
+	for _, chain := range chains {
+		forkUrl, err := common.GetForkUrlDefault(contextName, config, chain.Name)
+		if err != nil {
+			return fmt.Errorf("%s fork URL error: %w", chain.Name, err)
+		}
+		forkBlock := 0
+		if chain.Config.Fork != nil {
+			forkBlock = chain.Config.Fork.Block
+		}
+		if forkBlock < 0 {
+			return fmt.Errorf("invalid %s fork block %d", chain.Name, forkBlock)
+		}
+
+		var contracts []devnet.ForkContract
+		if chain.isL1() && envCtx.EigenLayer != nil {
+			for _, field := range envCtx.EigenLayer.L1.NamedAddresses() {
+				if field[1] != "" {
+					contracts = append(contracts, devnet.ForkContract{Name: "eigenlayer.l1." + field[0], Address: ethcommon.HexToAddress(field[1])})
+				}
+			}
+		}
+
+		logger.Info("Checking the %s fork at %s...", chain.Name, describeForkBlock(forkBlock))
+		if err := devnet.ValidateFork(cCtx.Context, forkUrl, uint64(forkBlock), contracts); err != nil {
+			return fmt.Errorf("%s fork check failed (use --skip-fork-check to start anyway): %w", chain.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Lifecycle hooks are optional .devkit/scripts the devnet runs with the context JSON at fixed phases
+const (
+	hookPreDevnetStart          = "preDevnetStart"
+	hookPostContractsDeployed   = "postContractsDeployed"
+	hookPostOperatorsRegistered = "postOperatorsRegistered"
+	hookPreDevnetStop           = "preDevnetStop"
+)
+
+// devnetHookPath returns the script path of a lifecycle hook
+func devnetHookPath(hook string) string {
+	return filepath.Join(".devkit", "scripts", hook)
+}
+
+// runDevnetHook runs a lifecycle hook if the project provides one. The context JSON points at the ports recorded
+// in state, a nil state passes the context as configured.
+func runDevnetHook(cCtx *cli.Context, logger iface.Logger, contextName string, hook string, state *devnet.State) error {
+	scriptPath := devnetHookPath(hook)
+	if _, err := os.Stat(scriptPath); errors.Is(err, os.ErrNotExist) {
+		logger.Debug("No %s hook found at %s", hook, scriptPath)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", scriptPath, err)
+	}
+
+	contextJSON, _, err := common.LoadRawContext(contextName)
+	if err != nil {
+		return fmt.Errorf("failed to load context: %w", err)
+	}
+	if state != nil {
+		if contextJSON, err = state.ApplyRPCURLsToRawContext(contextJSON); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Running %s hook...", hook)
+	if _, err := common.CallTemplateScript(cCtx.Context, logger, "", scriptPath, common.ExpectNonJSONResponse, contextJSON); err != nil {
+		return fmt.Errorf("%s hook failed: %w", hook, err)
+	}
+	return nil
+}
+
+// devnetHookStep runs a lifecycle hook as a setup step, so a hook which completed is not repeated on --resume
+func devnetHookStep(contextName string, hook string, state *devnet.State) SetupStep {
+	return SetupStep{
+		Name: hook,
+		Action: func(cCtx *cli.Context, logger iface.Logger) error {
+			return runDevnetHook(cCtx, logger, contextName, hook, state)
+		},
+		ErrMsg: "devnet lifecycle hook failed",
+	}
+}
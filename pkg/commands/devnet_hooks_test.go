@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestRunDevnetHook(t *testing.T) {
+	tmpDir, err := testutils.CreateTempAVSProject(t)
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	cCtx := &cli.Context{Context: context.Background()}
+	log := logger.NewNoopLogger()
+
+	// Projects without the hook script are left alone
+	require.NoError(t, runDevnetHook(cCtx, log, devnet.DEVNET_CONTEXT, hookPostContractsDeployed, nil))
+
+	// The hook receives the context JSON pointed at the ports recorded in state
+	hook := "#!/bin/bash\necho \"$1\" > hook-input.json\n"
+	require.NoError(t, os.WriteFile(devnetHookPath(hookPostContractsDeployed), []byte(hook), 0755))
+	state := &devnet.State{Context: devnet.DEVNET_CONTEXT, L1Port: 18545, L2Port: 19545}
+	require.NoError(t, runDevnetHook(cCtx, log, devnet.DEVNET_CONTEXT, hookPostContractsDeployed, state))
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "hook-input.json"))
+	require.NoError(t, err)
+	var input struct {
+		Context struct {
+			Chains map[string]struct {
+				RPCURL string `json:"rpc_url"`
+			} `json:"chains"`
+		} `json:"context"`
+	}
+	require.NoError(t, json.Unmarshal(data, &input))
+	assert.Equal(t, devnet.GetRPCURL(18545), input.Context.Chains["l1"].RPCURL)
+	assert.Equal(t, devnet.GetRPCURL(19545), input.Context.Chains["l2"].RPCURL)
+
+	// A failing hook fails the phase
+	require.NoError(t, os.WriteFile(devnetHookPath(hookPostContractsDeployed), []byte("#!/bin/bash\nexit 3\n"), 0755))
+	err = runDevnetHook(cCtx, log, devnet.DEVNET_CONTEXT, hookPostContractsDeployed, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "postContractsDeployed hook failed")
+	assert.Contains(t, err.Error(), "exited with code 3")
+}
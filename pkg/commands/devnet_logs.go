@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/urfave/cli/v2"
+)
+
+// logsCommand tails the devnet and AVS component containers under `devkit avs devnet logs`
+var logsCommand = &cli.Command{
+	Name:  "logs",
+	Usage: "Show the logs of the devnet chains, extra services and AVS components (aggregator, executor)",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "context",
+			Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+		},
+		&cli.BoolFlag{
+			Name:    "follow",
+			Aliases: []string{"f"},
+			Usage:   "Keep streaming new log lines until interrupted",
+		},
+		&cli.StringSliceFlag{
+			Name:  "service",
+			Usage: "Only show the named service (l1, l2, aggregator, executor or a context service), can be repeated",
+		},
+		&cli.StringFlag{
+			Name:  "tail",
+			Usage: "Number of lines to show from the end of each container's logs, or \"all\"",
+			Value: "all",
+		},
+	}, common.GlobalFlags...),
+	Action: DevnetLogsAction,
+}
+
+// DevnetLogsAction prints the docker logs of the project's devnet containers, each line prefixed with its service
+func DevnetLogsAction(cCtx *cli.Context) error {
+	// Load config for selected context
+	contextName := cCtx.String("context")
+	var cfg *common.ConfigWithContextConfig
+	var err error
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	if contextName != devnet.DEVNET_CONTEXT {
+		return fmt.Errorf("logs are only available on devnet - please run with `--context devnet`")
+	}
+
+	sources, err := devnet.DevnetLogSources(cCtx.Context, cfg.Config.Project.Name, contextName)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("no running devnet containers found for project %s, start one with `devkit avs devnet start`", cfg.Config.Project.Name)
+	}
+	sources, err = devnet.SelectLogSources(sources, cCtx.StringSlice("service"))
+	if err != nil {
+		return err
+	}
+
+	// Stop following on Ctrl-C
+	ctx, stop := signal.NotifyContext(cCtx.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return devnet.StreamLogs(ctx, cCtx.App.Writer, sources, cCtx.Bool("follow"), cCtx.String("tail"))
+}
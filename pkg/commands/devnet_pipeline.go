@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/urfave/cli/v2"
+)
+
+// SetupStep is a single named step of the devnet/deploy setup pipeline
+type SetupStep struct {
+	// Name identifies the step in the devnet state file and names its `devnet` subcommand
+	Name string
+	// Usage describes the step when surfaced as a subcommand
+	Usage string
+	// Flags are the step specific flags accepted by its subcommand
+	Flags []cli.Flag
+	// Action performs the step
+	Action func(cCtx *cli.Context, logger iface.Logger) error
+	// ErrMsg prefixes any error returned by Action
+	ErrMsg string
+	// DevnetOnly marks steps which only make sense against a local devnet (funding, storage writes, ...)
+	DevnetOnly bool
+}
+
+// bootstrapEigenLayerStep deploys the EigenLayer core contracts when the devnet is started without a fork
+func bootstrapEigenLayerStep() SetupStep {
+	return SetupStep{Name: "bootstrap-eigenlayer", Usage: "Deploy the EigenLayer core contracts to non-forked devnet chains", Action: BootstrapEigenLayerAction, ErrMsg: "bootstrapping eigenlayer failed", DevnetOnly: true}
+}
+
+// devnetFundingSteps returns the steps which fund devnet wallets and stakers before anything is deployed
+func devnetFundingSteps() []SetupStep {
+	return []SetupStep{
+		{Name: "fund-wallets", Usage: "Fund operator and transporter wallets on L1 and L2", Action: FundDevnetWalletsAction, ErrMsg: "funding devnet wallets failed", DevnetOnly: true},
+		{Name: "fund-stakers", Usage: "Fund stakers with their strategies' underlying tokens", Action: FundStakersWithStrategyTokensAction, ErrMsg: "funding stakers failed", DevnetOnly: true},
+		{Name: "fund", Usage: "Fund the accounts declared in the context's funding section with ETH and ERC20 tokens", Action: FundDevnetAccountsAction, ErrMsg: "funding accounts failed", DevnetOnly: true},
+	}
+}
+
+// whitelistChainIdStep whitelists the devnet chain ids in the CrossChainRegistry
+func whitelistChainIdStep() SetupStep {
+	return SetupStep{Name: "whitelist-chain-id", Usage: "Whitelist the devnet chain ids in the CrossChainRegistry", Action: WhitelistChainIdInCrossRegistryAction, ErrMsg: "whitelisting chain id in cross registry failed", DevnetOnly: true}
+}
+
+// deployL1ContractsStep deploys the project's L1 contracts through the deployL1Contracts script
+func deployL1ContractsStep() SetupStep {
+	return SetupStep{
+		Name: "deploy-l1-contracts",
+		Action: func(cCtx *cli.Context, logger iface.Logger) error {
+			return DeployL1ContractsAction(cCtx)
+		},
+		ErrMsg: "deploy-contracts failed",
+	}
+}
+
+// avsSetupSteps returns the ordered steps which register the AVS, its operator sets and operators with EigenLayer
+func avsSetupSteps(includeDevnetOnly bool) []SetupStep {
+	steps := []SetupStep{
+		{Name: "update-avs-metadata", Usage: "Update the AVS metadata URI", Flags: []cli.Flag{&cli.StringFlag{Name: "uri", Usage: "Metadata URI to set for the AVS"}}, Action: UpdateAVSMetadataAction, ErrMsg: "updating AVS metadata failed"},
+		{Name: "set-avs-registrar", Usage: "Set the AVS registrar to the deployed AVSRegistrar contract", Action: SetAVSRegistrarAction, ErrMsg: "setting AVS registrar failed"},
+		{Name: "create-avs-operator-sets", Usage: "Create the AVS operator sets defined in context", Flags: []cli.Flag{operatorSetFilterFlag}, Action: CreateAVSOperatorSetsAction, ErrMsg: "creating AVS operator sets failed"},
+		{Name: "configure-curve-type", Usage: "Configure the curve type of each operator set in the KeyRegistrar", Flags: []cli.Flag{operatorSetFilterFlag}, Action: ConfigureOpSetCurveTypeAction, ErrMsg: "failed to configure OpSet in KeyRegistrar"},
+		{Name: "create-generation-reservation", Usage: "Request a generation reservation for each operator set", Flags: []cli.Flag{operatorSetFilterFlag}, Action: CreateGenerationReservationAction, ErrMsg: "failed to request op set generation reservation"},
+		{Name: "register-operators-to-eigenlayer", Usage: "Register the operators defined in context with EigenLayer", Flags: []cli.Flag{operatorFilterFlag}, Action: RegisterOperatorsToEigenLayerFromConfigAction, ErrMsg: "registering operators failed"},
+		{Name: "register-keys", Usage: "Register operator keys in the KeyRegistrar", Flags: []cli.Flag{operatorFilterFlag, operatorSetFilterFlag}, Action: RegisterKeyInKeyRegistrarAction, ErrMsg: "registering key in key registrar failed"},
+		{Name: "deposit-into-strategies", Usage: "Deposit staker funds into their strategies", Flags: []cli.Flag{operatorFilterFlag}, Action: DepositIntoStrategiesAction, ErrMsg: "depositing into strategies failed", DevnetOnly: true},
+		{Name: "delegate-to-operators", Usage: "Delegate stakers to their operators", Flags: []cli.Flag{operatorFilterFlag}, Action: DelegateToOperatorsAction, ErrMsg: "delegating to operators failed", DevnetOnly: true},
+		{Name: "set-allocation-delay", Usage: "Bypass the allocation configuration delay for operators", Flags: []cli.Flag{operatorFilterFlag}, Action: SetAllocationDelayAction, ErrMsg: "setting allocation delay failed", DevnetOnly: true},
+		{Name: "modify-allocations", Usage: "Allocate operator stake to operator sets", Flags: []cli.Flag{operatorFilterFlag, operatorSetFilterFlag}, Action: ModifyAllocationsAction, ErrMsg: "modifying allocations failed", DevnetOnly: true},
+		{Name: "register-operators-to-avs", Usage: "Register operators to the AVS operator sets", Flags: []cli.Flag{operatorFilterFlag, operatorSetFilterFlag}, Action: RegisterOperatorsToAvsFromConfigAction, ErrMsg: "registering operators to AVS failed"},
+	}
+
+	if includeDevnetOnly {
+		return steps
+	}
+
+	filtered := make([]SetupStep, 0, len(steps))
+	for _, step := range steps {
+		if !step.DevnetOnly {
+			filtered = append(filtered, step)
+		}
+	}
+	return filtered
+}
+
+// runSetupPipeline executes steps in order, skipping any already recorded as complete in state.
+// Each successful step is checkpointed to the state file so a failed run can be resumed. A nil state disables checkpointing.
+func runSetupPipeline(cCtx *cli.Context, logger iface.Logger, steps []SetupStep, state *devnet.State) error {
+	for _, step := range steps {
+		if state != nil && state.IsStepComplete(step.Name) {
+			logger.Info("Skipping completed step: %s", step.Name)
+			continue
+		}
+
+		logger.Debug("Running setup step: %s", step.Name)
+		if err := step.Action(cCtx, logger); err != nil {
+			return fmt.Errorf("%s: %w", step.ErrMsg, err)
+		}
+
+		if state != nil {
+			state.MarkStepComplete(step.Name)
+			if err := devnet.SaveState(state); err != nil {
+				return fmt.Errorf("failed to checkpoint step %s: %w", step.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// setupStepCommands surfaces each setup step as a standalone `devnet` subcommand so it can be re-run against running chains
+func setupStepCommands() []*cli.Command {
+	steps := append([]SetupStep{bootstrapEigenLayerStep()}, devnetFundingSteps()...)
+	steps = append(steps, whitelistChainIdStep())
+	steps = append(steps, avsSetupSteps(true)...)
+
+	cmds := make([]*cli.Command, 0, len(steps))
+	for _, step := range steps {
+		flags := append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "context",
+				Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+			},
+		}, step.Flags...)
+
+		cmds = append(cmds, &cli.Command{
+			Name:  step.Name,
+			Usage: step.Usage,
+			Flags: append(flags, common.GlobalFlags...),
+			Action: func(cCtx *cli.Context) error {
+				logger := common.LoggerFromContext(cCtx.Context)
+				if err := step.Action(cCtx, logger); err != nil {
+					return fmt.Errorf("%s: %w", step.ErrMsg, err)
+				}
+				return nil
+			},
+		})
+	}
+	return cmds
+}
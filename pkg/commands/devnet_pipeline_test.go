@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestRunSetupPipelineCheckpointsAndResumes(t *testing.T) {
+	// Run inside a temp project dir so the state file is isolated
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	var ran []string
+	failOn := "second"
+	newStep := func(name string) SetupStep {
+		return SetupStep{
+			Name: name,
+			Action: func(cCtx *cli.Context, logger iface.Logger) error {
+				ran = append(ran, name)
+				if name == failOn {
+					return errors.New("boom")
+				}
+				return nil
+			},
+			ErrMsg: name + " failed",
+		}
+	}
+	steps := []SetupStep{newStep("first"), newStep("second"), newStep("third")}
+	log := logger.NewNoopLogger()
+
+	// The first run stops at the failing step and records the steps before it
+	state := &devnet.State{Context: "devnet"}
+	err = runSetupPipeline(&cli.Context{}, log, steps, state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "second failed: boom")
+	assert.Equal(t, []string{"first", "second"}, ran)
+
+	// The checkpoint is persisted to disk
+	loaded, err := devnet.LoadState("devnet")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first"}, loaded.CompletedSteps)
+
+	// Resuming from the loaded state skips the completed step
+	ran = nil
+	failOn = ""
+	require.NoError(t, runSetupPipeline(&cli.Context{}, log, steps, loaded))
+	assert.Equal(t, []string{"second", "third"}, ran)
+
+	loaded, err = devnet.LoadState("devnet")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "third"}, loaded.CompletedSteps)
+}
+
+func TestRunSetupPipelineWithoutState(t *testing.T) {
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	calls := 0
+	step := SetupStep{
+		Name: "only",
+		Action: func(cCtx *cli.Context, logger iface.Logger) error {
+			calls++
+			return nil
+		},
+	}
+
+	require.NoError(t, runSetupPipeline(&cli.Context{}, logger.NewNoopLogger(), []SetupStep{step, step}, nil))
+	assert.Equal(t, 2, calls)
+
+	// No checkpoint is written when state is nil
+	_, err = os.Stat(devnet.StatePath("devnet"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAVSSetupStepsFiltersDevnetOnly(t *testing.T) {
+	all := avsSetupSteps(true)
+	deployOnly := avsSetupSteps(false)
+
+	assert.Greater(t, len(all), len(deployOnly))
+	for _, step := range deployOnly {
+		assert.False(t, step.DevnetOnly, "step %s should not be devnet only", step.Name)
+	}
+
+	// Names must be unique since they key the checkpoint file
+	seen := map[string]bool{}
+	for _, step := range all {
+		assert.False(t, seen[step.Name], "duplicate step name %s", step.Name)
+		seen[step.Name] = true
+	}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/urfave/cli/v2"
+)
+
+// readyTimeoutFlag bounds how long devnet startup waits for the chains to answer
+var readyTimeoutFlag = &cli.DurationFlag{
+	Name:  "ready-timeout",
+	Usage: "How long to wait for the L1/L2 chains to become ready",
+	Value: devnet.DEFAULT_READY_TIMEOUT,
+}
+
+// readyBackoffFlag sets the initial delay between readiness probes
+var readyBackoffFlag = &cli.DurationFlag{
+	Name:  "ready-backoff",
+	Usage: "Initial delay between readiness probes, doubled after each failed probe",
+	Value: devnet.DEFAULT_READY_BACKOFF,
+}
+
+// waitForDevnetReady blocks until every devnet chain answers with its configured chain id and, when forked,
+// has reached the configured fork block
+func waitForDevnetReady(cCtx *cli.Context, logger iface.Logger, cfg *common.ConfigWithContextConfig, chains []devnetChain, noFork bool) error {
+	readiness := devnet.DefaultReadinessConfig()
+	if cCtx.IsSet(readyTimeoutFlag.Name) {
+		readiness.Timeout = cCtx.Duration(readyTimeoutFlag.Name)
+	}
+	if cCtx.IsSet(readyBackoffFlag.Name) {
+		readiness.InitialBackoff = cCtx.Duration(readyBackoffFlag.Name)
+	}
+
+	probes := make([]devnet.ChainProbe, 0, len(chains))
+	for _, chain := range chains {
+		probe := devnet.ChainProbe{Name: chain.Name, RPCURL: devnet.GetRPCURL(chain.Port)}
+		if chainId, err := devnet.GetDevnetChainIdOrDefault(cfg, chain.Name, logger); err == nil {
+			probe.ChainID = uint64(chainId)
+		}
+		if !noFork && chain.Config.Fork != nil && chain.Config.Fork.Block > 0 {
+			probe.MinBlock = uint64(chain.Config.Fork.Block)
+		}
+		probes = append(probes, probe)
+	}
+
+	tracker := common.ProgressTrackerFromContext(cCtx.Context)
+	defer tracker.Clear()
+	if err := devnet.WaitForChains(cCtx.Context, probes, readiness, tracker); err != nil {
+		return fmt.Errorf("devnet failed to become ready: %w", err)
+	}
+	return nil
+}
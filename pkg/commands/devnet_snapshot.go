@@ -0,0 +1,323 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// SnapshotDevnetAction captures the L1/L2 anvil state together with the context yaml into .devkit/snapshots/<name>
+func SnapshotDevnetAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	// Validate the snapshot name
+	name := cCtx.Args().First()
+	if name == "" {
+		return fmt.Errorf("snapshot name is required: devkit avs devnet snapshot <name>")
+	}
+	if err := devnet.ValidateSnapshotName(name); err != nil {
+		return err
+	}
+
+	// Load config for selected context
+	contextName := cCtx.String("context")
+	var cfg *common.ConfigWithContextConfig
+	var err error
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	if contextName != devnet.DEVNET_CONTEXT {
+		return fmt.Errorf("snapshots are only available on devnet - please run with `--context devnet`")
+	}
+
+	// Extract context details
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+
+	// Refuse to overwrite an existing snapshot unless forced
+	snapshotDir := devnet.SnapshotPath(name)
+	if _, err := os.Stat(snapshotDir); err == nil {
+		if !cCtx.Bool("force") {
+			return fmt.Errorf("snapshot %q already exists, use --force to overwrite it", name)
+		}
+		if err := os.RemoveAll(snapshotDir); err != nil {
+			return fmt.Errorf("failed to remove existing snapshot: %w", err)
+		}
+	}
+	if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot dir: %w", err)
+	}
+
+	meta := &devnet.SnapshotMetadata{
+		Name:      name,
+		Context:   contextName,
+		Project:   cfg.Config.Project.Name,
+		CreatedAt: time.Now().UTC(),
+		Chains:    map[string]devnet.SnapshotChain{},
+	}
+
+	// Dump the state of each chain
+	for _, chainName := range append([]string{common.L1}, common.L2ChainNames(envCtx.Chains)...) {
+		chainCfg, ok := envCtx.Chains[chainName]
+		if !ok {
+			return fmt.Errorf("failed to get %s chain config for context '%s'", chainName, contextName)
+		}
+
+		logger.Info("Dumping %s state from %s...", chainName, chainCfg.RPCURL)
+		backend, err := devnet.ChainBackendFor(chainName, chainCfg)
+		if err != nil {
+			return err
+		}
+		head, err := getChainHead(cCtx.Context, chainName, backend, chainCfg.RPCURL)
+		if err != nil {
+			return fmt.Errorf("failed to read %s head (is the devnet running?): %w", chainName, err)
+		}
+		state, err := devnet.DumpChainState(cCtx.Context, backend, chainCfg.RPCURL)
+		if err != nil {
+			return err
+		}
+
+		stateFile := devnet.SnapshotChainStateFile(chainName)
+		if err := os.WriteFile(filepath.Join(snapshotDir, stateFile), []byte(state), 0644); err != nil {
+			return fmt.Errorf("failed to write %s state: %w", chainName, err)
+		}
+		meta.Chains[chainName] = devnet.SnapshotChain{
+			ChainID:     uint64(chainCfg.ChainID),
+			BlockNumber: head.Block,
+			Timestamp:   head.Timestamp,
+			StateFile:   stateFile,
+		}
+	}
+
+	// Capture the context yaml matching the chain state
+	contextData, err := os.ReadFile(filepath.Join("config", "contexts", contextName+".yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to read context yaml: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(snapshotDir, devnet.SnapshotContextFile), contextData, 0644); err != nil {
+		return fmt.Errorf("failed to write context yaml to snapshot: %w", err)
+	}
+
+	// Capture the setup progress so a restored devnet can be resumed
+	if stateData, err := os.ReadFile(devnet.StatePath(contextName)); err == nil {
+		if err := os.WriteFile(filepath.Join(snapshotDir, devnet.SnapshotStateFile), stateData, 0644); err != nil {
+			return fmt.Errorf("failed to write devnet state to snapshot: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read devnet state: %w", err)
+	}
+
+	if err := devnet.WriteSnapshotMetadata(meta); err != nil {
+		return err
+	}
+
+	logger.Info("Snapshot %q saved to %s", name, snapshotDir)
+	return nil
+}
+
+// RestoreDevnetAction boots fresh devnet containers and loads a snapshot's L1/L2 state and context yaml into them
+func RestoreDevnetAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	// Validate the snapshot name
+	name := cCtx.Args().First()
+	if name == "" {
+		return fmt.Errorf("snapshot name is required: devkit avs devnet restore <name>")
+	}
+	if err := devnet.ValidateSnapshotName(name); err != nil {
+		return err
+	}
+	meta, err := devnet.ReadSnapshotMetadata(name)
+	if err != nil {
+		return err
+	}
+	snapshotDir := devnet.SnapshotPath(name)
+
+	// Check if docker is running, else try to start it
+	if err := common.EnsureDockerIsRunning(cCtx); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+		return cli.Exit(err.Error(), 1)
+	}
+
+	// Load config for the context captured with the snapshot, it is only written once the restore succeeded
+	contextName := meta.Context
+	if cCtx.IsSet("context") && cCtx.String("context") != contextName {
+		return fmt.Errorf("snapshot %q was taken from context '%s', not '%s'", name, contextName, cCtx.String("context"))
+	}
+	contextData, err := os.ReadFile(filepath.Join(snapshotDir, devnet.SnapshotContextFile))
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot context yaml: %w", err)
+	}
+	cfg, contextName, err := common.LoadConfigWithContextData(contextName, contextData)
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+
+	// Restore the setup progress captured with the snapshot, which also records whether the devnet was forked
+	state := &devnet.State{Context: contextName}
+	if stateData, err := os.ReadFile(filepath.Join(snapshotDir, devnet.SnapshotStateFile)); err == nil {
+		if err := json.Unmarshal(stateData, state); err != nil {
+			return fmt.Errorf("failed to parse snapshot devnet state: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read snapshot devnet state: %w", err)
+	}
+
+	// Resolve the chains of the restored context to their new ports and containers
+	l1Port, l2Port := cCtx.Int("l1-port"), cCtx.Int("l2-port")
+	var l2Ports map[string]int
+	autoPorts := cCtx.Bool("auto-ports")
+	if autoPorts {
+		if cCtx.IsSet("l1-port") || cCtx.IsSet("l2-port") {
+			return fmt.Errorf("--auto-ports cannot be combined with --l1-port or --l2-port")
+		}
+		if l1Port, l2Port, l2Ports, err = allocateDevnetPorts(envCtx); err != nil {
+			return err
+		}
+	}
+	namespace := devnet.Namespace(cfg.Config.Project.Name, contextName)
+	chains, err := resolveDevnetChains(envCtx, namespace, l1Port, l2Port, l2Ports)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we are not restoring over a running devnet
+	for _, chain := range chains {
+		running, err := devnet.IsContainerRunning(cCtx.Context, chain.ContainerName)
+		if err != nil {
+			return err
+		}
+		if running {
+			return fmt.Errorf("container %s is already running, stop the devnet with `devkit avs devnet stop` before restoring", chain.ContainerName)
+		}
+	}
+	if err := checkDevnetPortsAvailable(chains, envCtx.Services); err != nil {
+		return err
+	}
+
+	logger.Info("Restoring snapshot %q (created %s)...", name, meta.CreatedAt.Format(time.RFC3339))
+
+	// Stop the started containers unless the snapshot was fully restored into them
+	restored := false
+	defer func() {
+		if restored {
+			return
+		}
+		logger.Info("Stopping containers")
+		stopProjectContainers(&cli.Context{Context: context.Background()}, logger, cfg.Config.Project.Name, contextName)
+	}()
+
+	// The chains start without a fork: the dump holds every account and slot the devnet had read from its fork,
+	// so the restored devnet runs offline instead of reading the rest lazily from the fork provider
+	stopForkCache, err := startDevnetContainers(cCtx, logger, cfg, contextName, chains, true)
+	defer stopForkCache()
+	if err != nil {
+		return err
+	}
+
+	// Wait until every chain answers before loading state into it
+	if err := waitForDevnetReady(cCtx, logger, cfg, chains, true); err != nil {
+		return err
+	}
+
+	// Load the dumped state into each chain
+	rpcUrls := devnetRPCURLs(chains)
+	for _, chain := range chains {
+		snapshotChain, ok := meta.Chains[chain.Name]
+		if !ok {
+			return fmt.Errorf("snapshot %q has no %s state", name, chain.Name)
+		}
+		chainState, err := os.ReadFile(filepath.Join(snapshotDir, snapshotChain.StateFile))
+		if err != nil {
+			return fmt.Errorf("failed to read %s state: %w", chain.Name, err)
+		}
+
+		logger.Info("Loading %s state (block %d)...", chain.Name, snapshotChain.BlockNumber)
+		if err := devnet.LoadChainState(cCtx.Context, chain.Backend, rpcUrls[chain.Name], string(chainState)); err != nil {
+			return err
+		}
+
+		// Move the chain back to the head and clock it was dumped at
+		timeClient, err := devnet.DialTimeClient(cCtx.Context, chain.Name, chain.Backend, rpcUrls[chain.Name])
+		if err != nil {
+			return err
+		}
+		err = devnet.RestoreChainHead(cCtx.Context, timeClient, snapshotChain.BlockNumber, snapshotChain.Timestamp)
+		timeClient.Client.Close()
+		if err != nil {
+			return fmt.Errorf("failed to restore %s head: %w", chain.Name, err)
+		}
+	}
+
+	// Restore the context yaml captured with the snapshot, pointed at the new containers
+	var rootNode yaml.Node
+	if err := yaml.Unmarshal(contextData, &rootNode); err != nil {
+		return fmt.Errorf("failed to parse snapshot context yaml: %w", err)
+	}
+	if len(rootNode.Content) == 0 {
+		return fmt.Errorf("snapshot context yaml is empty")
+	}
+	contextNode := common.GetChildByKey(rootNode.Content[0], "context")
+	if contextNode == nil {
+		return fmt.Errorf("missing 'context' key in snapshot context yaml")
+	}
+	if err := setContextRPCURLs(contextNode, rpcUrls); err != nil {
+		return err
+	}
+	if err := common.WriteYAML(filepath.Join("config", "contexts", contextName+".yaml"), &rootNode); err != nil {
+		return fmt.Errorf("failed to restore context yaml: %w", err)
+	}
+
+	// Save the restored setup progress, recording the new ports
+	state.Context = contextName
+	state.Project = cfg.Config.Project.Name
+	state.Namespace = namespace
+	state.AutoPorts = autoPorts
+	state.L1Port = chains[0].Port
+	state.L2Port = chains[1].Port
+	state.L2Ports = extraL2Ports(chains)
+	if err := devnet.SaveState(state); err != nil {
+		return err
+	}
+	restored = true
+
+	logger.Info("Snapshot %q restored:", name)
+	for _, chain := range chains {
+		logger.Info(" - %s on port %d", strings.ToUpper(chain.Name), chain.Port)
+	}
+	logger.Info("Containers keep running until `devkit avs devnet stop`; run `devkit avs run` to start the AVS components")
+	return nil
+}
+
+// getChainHead returns the latest block number and timestamp served by rpcURL
+func getChainHead(ctx context.Context, name string, backend devnet.ChainBackend, rpcURL string) (devnet.ChainTime, error) {
+	chain, err := devnet.DialTimeClient(ctx, name, backend, rpcURL)
+	if err != nil {
+		return devnet.ChainTime{}, err
+	}
+	defer chain.Client.Close()
+	return devnet.GetTimestamp(ctx, chain)
+}
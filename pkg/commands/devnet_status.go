@@ -0,0 +1,399 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	"github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/IOperatorTableUpdater"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+)
+
+// statusRPCTimeout bounds each chain query made by `devnet status`, so a stopped devnet reports quickly
+const statusRPCTimeout = 5 * time.Second
+
+// devnetStatus is the report printed by `devkit avs devnet status`
+type devnetStatus struct {
+	Project       string               `json:"project"`
+	Context       string               `json:"context"`
+	Chains        []chainStatus        `json:"chains"`
+	Services      []serviceStatus      `json:"services,omitempty"`
+	Contracts     []contractStatus     `json:"contracts"`
+	Registrations []registrationStatus `json:"operator_registrations"`
+	StakeRoots    []stakeRootStatus    `json:"stake_roots"`
+}
+
+type chainStatus struct {
+	Name           string `json:"name"`
+	Container      string `json:"container"`
+	ContainerState string `json:"container_state"`
+	RPCURL         string `json:"rpc_url"`
+	ChainID        uint64 `json:"chain_id,omitempty"`
+	Block          uint64 `json:"block,omitempty"`
+	Timestamp      uint64 `json:"timestamp,omitempty"`
+	// DriftSeconds is the L2 timestamp minus the L1 timestamp, unset for the L1
+	DriftSeconds *int64 `json:"drift_seconds,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+type serviceStatus struct {
+	Name           string `json:"name"`
+	Container      string `json:"container"`
+	ContainerState string `json:"container_state"`
+}
+
+type contractStatus struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	HasCode bool   `json:"has_code"`
+	Error   string `json:"error,omitempty"`
+}
+
+type registrationStatus struct {
+	Operator      string `json:"operator"`
+	OperatorSetID uint64 `json:"operator_set_id"`
+	Registered    bool   `json:"registered"`
+	Error         string `json:"error,omitempty"`
+}
+
+type stakeRootStatus struct {
+	Chain              string `json:"chain"`
+	ChainID            uint64 `json:"chain_id"`
+	Root               string `json:"root,omitempty"`
+	ReferenceTimestamp uint32 `json:"reference_timestamp,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// StatusDevnetAction reports the health of the running devnet and what has been deployed to it
+func StatusDevnetAction(cCtx *cli.Context) error {
+	// Load config for selected context
+	contextName := cCtx.String("context")
+	var cfg *common.ConfigWithContextConfig
+	var err error
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configurations: %w", err)
+	}
+	if contextName != devnet.DEVNET_CONTEXT {
+		return fmt.Errorf("status is only available on devnet - please run with `--context devnet`")
+	}
+
+	// Extract context details
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+	if err := applyRecordedRPCURLs(envCtx, contextName); err != nil {
+		return err
+	}
+	allocationManager, _, _, _, _, _, _, _ := common.GetEigenLayerAddresses(contextName, cfg)
+
+	status := collectDevnetStatus(cCtx.Context, cfg.Config.Project.Name, contextName, envCtx, ethcommon.HexToAddress(allocationManager))
+	if cCtx.Bool("json") {
+		return writeDevnetStatusJSON(cCtx.App.Writer, status)
+	}
+	writeDevnetStatus(cCtx.App.Writer, status)
+	return nil
+}
+
+// collectDevnetStatus queries docker and the chains for each part of the report. Failures are recorded
+// against the entry they affect so a partially running devnet still produces a report.
+func collectDevnetStatus(ctx context.Context, projectName, contextName string, envCtx common.ChainContextConfig, allocationManager ethcommon.Address) *devnetStatus {
+	status := &devnetStatus{Project: projectName, Context: contextName}
+	namespace := devnet.Namespace(projectName, contextName)
+
+	// Chains, L1 first so the L2 drift can be computed against it
+	clients := map[string]*rpc.Client{}
+	defer func() {
+		for _, client := range clients {
+			client.Close()
+		}
+	}()
+	for _, name := range append([]string{common.L1}, common.L2ChainNames(envCtx.Chains)...) {
+		chain := chainStatus{
+			Name:      name,
+			Container: devnet.ChainContainerName(name, namespace),
+			RPCURL:    envCtx.Chains[name].RPCURL,
+		}
+		chain.ContainerState = containerStateOrUnknown(ctx, chain.Container)
+
+		client, err := queryChainStatus(ctx, &chain)
+		if err != nil {
+			chain.Error = err.Error()
+		} else {
+			clients[name] = client
+		}
+		if name != common.L1 && chain.Error == "" && len(status.Chains) > 0 && status.Chains[0].Error == "" {
+			drift := int64(chain.Timestamp) - int64(status.Chains[0].Timestamp)
+			chain.DriftSeconds = &drift
+		}
+		status.Chains = append(status.Chains, chain)
+	}
+
+	for _, svc := range envCtx.Services {
+		container := devnet.ServiceContainerName(svc.Name, namespace)
+		status.Services = append(status.Services, serviceStatus{
+			Name:           svc.Name,
+			Container:      container,
+			ContainerState: containerStateOrUnknown(ctx, container),
+		})
+	}
+
+	l1Client := clients[common.L1]
+	status.Contracts = collectContractStatuses(ctx, l1Client, envCtx.DeployedL1Contracts)
+	status.Registrations = collectRegistrationStatuses(ctx, l1Client, allocationManager, envCtx)
+	status.StakeRoots = collectStakeRootStatuses(ctx, clients, envCtx)
+	return status
+}
+
+// containerStateOrUnknown returns the container's docker state, "missing" if it does not exist and "unknown"
+// if docker could not be queried
+func containerStateOrUnknown(ctx context.Context, containerName string) string {
+	state, err := devnet.ContainerState(ctx, containerName)
+	switch {
+	case err != nil:
+		return "unknown"
+	case state == "":
+		return "missing"
+	default:
+		return state
+	}
+}
+
+// queryChainStatus fills in the chain id, head block and timestamp, returning the client for further queries
+func queryChainStatus(ctx context.Context, chain *chainStatus) (*rpc.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, statusRPCTimeout)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, chain.RPCURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", chain.RPCURL, err)
+	}
+
+	var chainID hexutil.Uint64
+	if err := client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("eth_chainId failed: %w", err)
+	}
+	var head struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	if err := client.CallContext(ctx, &head, "eth_getBlockByNumber", "latest", false); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("eth_getBlockByNumber failed: %w", err)
+	}
+
+	chain.ChainID = uint64(chainID)
+	chain.Block = uint64(head.Number)
+	chain.Timestamp = uint64(head.Timestamp)
+	return client, nil
+}
+
+// collectContractStatuses checks each deployed AVS contract has code on the L1
+func collectContractStatuses(ctx context.Context, l1Client *rpc.Client, contracts []common.DeployedL1Contracts) []contractStatus {
+	statuses := make([]contractStatus, 0, len(contracts))
+	for _, contract := range contracts {
+		status := contractStatus{Name: contract.Name, Address: contract.Address}
+		if l1Client == nil {
+			status.Error = "l1 unavailable"
+			statuses = append(statuses, status)
+			continue
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, statusRPCTimeout)
+		var code hexutil.Bytes
+		err := l1Client.CallContext(callCtx, &code, "eth_getCode", ethcommon.HexToAddress(contract.Address), "latest")
+		cancel()
+		if err != nil {
+			status.Error = fmt.Sprintf("eth_getCode failed: %v", err)
+		} else {
+			status.HasCode = len(code) > 0
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// collectRegistrationStatuses checks each operator registration in context against the AllocationManager
+func collectRegistrationStatuses(ctx context.Context, l1Client *rpc.Client, allocationManager ethcommon.Address, envCtx common.ChainContextConfig) []registrationStatus {
+	statuses := make([]registrationStatus, 0, len(envCtx.OperatorRegistrations))
+	if len(envCtx.OperatorRegistrations) == 0 {
+		return statuses
+	}
+
+	var caller *allocationmanager.AllocationManagerCaller
+	var callerErr error
+	if l1Client == nil {
+		callerErr = fmt.Errorf("l1 unavailable")
+	} else {
+		caller, callerErr = allocationmanager.NewAllocationManagerCaller(allocationManager, ethclient.NewClient(l1Client))
+	}
+
+	avsAddress := ethcommon.HexToAddress(envCtx.Avs.Address)
+	for _, reg := range envCtx.OperatorRegistrations {
+		status := registrationStatus{Operator: reg.Address, OperatorSetID: reg.OperatorSetID}
+		if callerErr != nil {
+			status.Error = callerErr.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, statusRPCTimeout)
+		registered, err := caller.IsMemberOfOperatorSet(&bind.CallOpts{Context: callCtx}, ethcommon.HexToAddress(reg.Address), allocationmanager.OperatorSet{
+			Avs: avsAddress,
+			Id:  uint32(reg.OperatorSetID),
+		})
+		cancel()
+		if err != nil {
+			status.Error = fmt.Sprintf("isMemberOfOperatorSet failed: %v", err)
+		} else {
+			status.Registered = registered
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// collectStakeRootStatuses reads the latest global table root transported to each L2's OperatorTableUpdater
+func collectStakeRootStatuses(ctx context.Context, clients map[string]*rpc.Client, envCtx common.ChainContextConfig) []stakeRootStatus {
+	var statuses []stakeRootStatus
+	for _, name := range common.L2ChainNames(envCtx.Chains) {
+		status := stakeRootStatus{Chain: name, ChainID: uint64(envCtx.Chains[name].ChainID)}
+		client, ok := clients[name]
+		switch {
+		case !ok:
+			status.Error = fmt.Sprintf("%s unavailable", name)
+		case envCtx.EigenLayer == nil || envCtx.EigenLayer.L2.OperatorTableUpdater == "":
+			status.Error = "operator_table_updater not set in context"
+		default:
+			if err := queryStakeRoot(ctx, client, ethcommon.HexToAddress(envCtx.EigenLayer.L2.OperatorTableUpdater), &status); err != nil {
+				status.Error = err.Error()
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func queryStakeRoot(ctx context.Context, client *rpc.Client, updaterAddress ethcommon.Address, status *stakeRootStatus) error {
+	ctx, cancel := context.WithTimeout(ctx, statusRPCTimeout)
+	defer cancel()
+
+	updater, err := IOperatorTableUpdater.NewIOperatorTableUpdaterCaller(updaterAddress, ethclient.NewClient(client))
+	if err != nil {
+		return fmt.Errorf("failed to bind OperatorTableUpdater: %w", err)
+	}
+	root, err := updater.GetCurrentGlobalTableRoot(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("getCurrentGlobalTableRoot failed: %w", err)
+	}
+	timestamp, err := updater.GetLatestReferenceTimestamp(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("getLatestReferenceTimestamp failed: %w", err)
+	}
+
+	// A zero root means nothing has been transported yet
+	if root != ([32]byte{}) {
+		status.Root = hexutil.Encode(root[:])
+		status.ReferenceTimestamp = timestamp
+	}
+	return nil
+}
+
+func writeDevnetStatusJSON(w io.Writer, status *devnetStatus) error {
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode devnet status: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeDevnetStatus prints the report as colored sections
+func writeDevnetStatus(w io.Writer, status *devnetStatus) {
+	fmt.Fprintf(w, "%s📦 Devnet status for %s (%s)%s\n\n", devnet.Blue, status.Project, status.Context, devnet.Reset)
+
+	fmt.Fprintf(w, "%sChains:%s\n", devnet.Cyan, devnet.Reset)
+	for _, chain := range status.Chains {
+		fmt.Fprintf(w, "  - %-8s %s %s\n", chain.Name, chain.Container, colorState(chain.ContainerState))
+		if chain.Error != "" {
+			fmt.Fprintf(w, "      %s%s%s\n", devnet.Yellow, chain.Error, devnet.Reset)
+			continue
+		}
+		line := fmt.Sprintf("chain id %d, block %d, timestamp %d", chain.ChainID, chain.Block, chain.Timestamp)
+		if chain.DriftSeconds != nil {
+			line += fmt.Sprintf(", drift %+ds from l1", *chain.DriftSeconds)
+		}
+		fmt.Fprintf(w, "      %s (%s)\n", line, chain.RPCURL)
+	}
+
+	if len(status.Services) > 0 {
+		fmt.Fprintf(w, "\n%sServices:%s\n", devnet.Cyan, devnet.Reset)
+		for _, svc := range status.Services {
+			fmt.Fprintf(w, "  - %-8s %s %s\n", svc.Name, svc.Container, colorState(svc.ContainerState))
+		}
+	}
+
+	fmt.Fprintf(w, "\n%sAVS contracts (l1):%s\n", devnet.Cyan, devnet.Reset)
+	if len(status.Contracts) == 0 {
+		fmt.Fprintf(w, "  none deployed\n")
+	}
+	for _, contract := range status.Contracts {
+		fmt.Fprintf(w, "  - %-24s %s %s\n", contract.Name, contract.Address, checkMark(contract.HasCode, "code", "no code", contract.Error))
+	}
+
+	fmt.Fprintf(w, "\n%sOperator registrations:%s\n", devnet.Cyan, devnet.Reset)
+	if len(status.Registrations) == 0 {
+		fmt.Fprintf(w, "  none configured\n")
+	}
+	for _, reg := range status.Registrations {
+		fmt.Fprintf(w, "  - %s set %d %s\n", reg.Operator, reg.OperatorSetID, checkMark(reg.Registered, "registered", "not registered", reg.Error))
+	}
+
+	fmt.Fprintf(w, "\n%sStake roots:%s\n", devnet.Cyan, devnet.Reset)
+	for _, root := range status.StakeRoots {
+		switch {
+		case root.Error != "":
+			fmt.Fprintf(w, "  - %-8s %s%s%s\n", root.Chain, devnet.Yellow, root.Error, devnet.Reset)
+		case root.Root == "":
+			fmt.Fprintf(w, "  - %-8s %snot transported yet%s\n", root.Chain, devnet.Yellow, devnet.Reset)
+		default:
+			fmt.Fprintf(w, "  - %-8s %s at reference timestamp %d\n", root.Chain, root.Root, root.ReferenceTimestamp)
+		}
+	}
+}
+
+func colorState(state string) string {
+	color := devnet.Yellow
+	if state == "running" {
+		color = devnet.Green
+	}
+	return fmt.Sprintf("%s[%s]%s", color, strings.ToLower(state), devnet.Reset)
+}
+
+func checkMark(ok bool, yes, no, errMsg string) string {
+	switch {
+	case errMsg != "":
+		return fmt.Sprintf("%s%s%s", devnet.Yellow, errMsg, devnet.Reset)
+	case ok:
+		return fmt.Sprintf("%s✅ %s%s", devnet.Green, yes, devnet.Reset)
+	default:
+		return fmt.Sprintf("%s❌ %s%s", devnet.Yellow, no, devnet.Reset)
+	}
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusFakeChain answers the queries made by `devnet status`
+type statusFakeChain struct {
+	chainID   uint64
+	block     uint64
+	timestamp uint64
+	code      map[ethcommon.Address]hexutil.Bytes
+}
+
+func (f *statusFakeChain) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(f.chainID)
+}
+
+func (f *statusFakeChain) GetBlockByNumber(tag string, full bool) map[string]interface{} {
+	return map[string]interface{}{
+		"number":    hexutil.Uint64(f.block),
+		"timestamp": hexutil.Uint64(f.timestamp),
+	}
+}
+
+func (f *statusFakeChain) GetCode(address ethcommon.Address, tag string) hexutil.Bytes {
+	return f.code[address]
+}
+
+func TestCollectDevnetStatus(t *testing.T) {
+	deployed := ethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	l1 := &statusFakeChain{chainID: 31337, block: 100, timestamp: 1_700_000_000, code: map[ethcommon.Address]hexutil.Bytes{deployed: {0x60, 0x80}}}
+	l2 := &statusFakeChain{chainID: 31338, block: 50, timestamp: 1_700_000_012}
+
+	envCtx := common.ChainContextConfig{
+		Chains: map[string]common.ChainConfig{
+			"l1": {ChainID: 31337, RPCURL: testutils.ServeRPC(t, map[string]any{"eth": l1})},
+			"l2": {ChainID: 31338, RPCURL: testutils.ServeRPC(t, map[string]any{"eth": l2})},
+		},
+		DeployedL1Contracts: []common.DeployedL1Contracts{
+			{Name: "taskAVSRegistrar", Address: deployed.Hex()},
+			{Name: "missing", Address: "0x2000000000000000000000000000000000000002"},
+		},
+	}
+
+	status := collectDevnetStatus(context.Background(), "demo", "devnet", envCtx, ethcommon.Address{})
+
+	require.Len(t, status.Chains, 2)
+	assert.Equal(t, "devkit-devnet-l1-demo-devnet", status.Chains[0].Container)
+	assert.Equal(t, uint64(31337), status.Chains[0].ChainID)
+	assert.Equal(t, uint64(100), status.Chains[0].Block)
+	assert.Nil(t, status.Chains[0].DriftSeconds)
+	require.NotNil(t, status.Chains[1].DriftSeconds)
+	assert.Equal(t, int64(12), *status.Chains[1].DriftSeconds)
+
+	require.Len(t, status.Contracts, 2)
+	assert.True(t, status.Contracts[0].HasCode)
+	assert.False(t, status.Contracts[1].HasCode)
+	assert.Empty(t, status.Contracts[1].Error)
+
+	assert.Empty(t, status.Registrations)
+	require.Len(t, status.StakeRoots, 1)
+	assert.Equal(t, "operator_table_updater not set in context", status.StakeRoots[0].Error)
+}
+
+func TestCollectDevnetStatusChainDown(t *testing.T) {
+	envCtx := common.ChainContextConfig{
+		Chains: map[string]common.ChainConfig{
+			"l1": {ChainID: 31337, RPCURL: "http://127.0.0.1:1"},
+			"l2": {ChainID: 31338, RPCURL: "http://127.0.0.1:1"},
+		},
+		OperatorRegistrations: []common.OperatorRegistration{{Address: "0x90F7", OperatorSetID: 0}},
+	}
+
+	status := collectDevnetStatus(context.Background(), "demo", "devnet", envCtx, ethcommon.Address{})
+
+	require.Len(t, status.Chains, 2)
+	assert.NotEmpty(t, status.Chains[0].Error)
+	assert.Nil(t, status.Chains[1].DriftSeconds)
+	require.Len(t, status.Registrations, 1)
+	assert.Equal(t, "l1 unavailable", status.Registrations[0].Error)
+	assert.Equal(t, "l2 unavailable", status.StakeRoots[0].Error)
+}
+
+func TestWriteDevnetStatus(t *testing.T) {
+	drift := int64(-3)
+	status := &devnetStatus{
+		Project: "demo",
+		Context: "devnet",
+		Chains: []chainStatus{
+			{Name: "l1", Container: "devkit-devnet-l1-demo", ContainerState: "running", ChainID: 31337, Block: 10, Timestamp: 100},
+			{Name: "l2", Container: "devkit-devnet-l2-demo", ContainerState: "running", ChainID: 31338, Block: 5, Timestamp: 97, DriftSeconds: &drift},
+		},
+		Contracts:     []contractStatus{{Name: "taskAVSRegistrar", Address: "0x01", HasCode: true}},
+		Registrations: []registrationStatus{{Operator: "0x90F7", OperatorSetID: 1}},
+		StakeRoots:    []stakeRootStatus{{Chain: "l2", ChainID: 31338, Root: "0xabc", ReferenceTimestamp: 99}},
+	}
+
+	var text bytes.Buffer
+	writeDevnetStatus(&text, status)
+	assert.Contains(t, text.String(), "chain id 31338, block 5, timestamp 97, drift -3s from l1")
+	assert.Contains(t, text.String(), "✅ code")
+	assert.Contains(t, text.String(), "0x90F7 set 1")
+	assert.Contains(t, text.String(), "❌ not registered")
+	assert.Contains(t, text.String(), "0xabc at reference timestamp 99")
+
+	var out bytes.Buffer
+	require.NoError(t, writeDevnetStatusJSON(&out, status))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "demo", decoded["project"])
+	chains := decoded["chains"].([]interface{})
+	assert.Equal(t, float64(-3), chains[1].(map[string]interface{})["drift_seconds"])
+}
@@ -11,12 +11,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
 	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
 	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
 )
 
 // TestDevnetPortAvailability tests port availability checking
@@ -174,12 +176,21 @@ func TestDevnetCommandFlags(t *testing.T) {
 
 // TestDevnetDockerComposeGeneration tests Docker compose file generation
 func TestDevnetDockerComposeGeneration(t *testing.T) {
-	// Test that we can generate the docker-compose file
-	composePath := devnet.WriteEmbeddedArtifacts()
+	// Test that we can generate the docker-compose file with one service per chain
+	composePath, err := devnet.WriteDockerCompose(devnet.ComposeConfig{
+		Project: "test-project",
+		Image:   devnet.FOUNDRY_IMAGE,
+		Chains: []devnet.ComposeChain{
+			{Name: "l1", ContainerName: "devkit-devnet-l1-test-project", Port: 8545, Command: []string{"--host", "0.0.0.0", "--chain-id", "31337"}},
+			{Name: "l2", ContainerName: "devkit-devnet-l2-test-project", Port: 9545, Command: []string{"--host", "0.0.0.0", "--chain-id", "31338"}},
+			{Name: "l2-op", ContainerName: "devkit-devnet-l2-op-test-project", Port: 9546, Command: []string{"--host", "0.0.0.0", "--chain-id", "31339"}},
+		},
+	})
+	require.NoError(t, err)
 	defer os.Remove(composePath)
 
 	// File should exist
-	_, err := os.Stat(composePath)
+	_, err = os.Stat(composePath)
 	assert.NoError(t, err)
 
 	// Read and validate content
@@ -190,6 +201,22 @@ func TestDevnetDockerComposeGeneration(t *testing.T) {
 	assert.Contains(t, contentStr, "services:")
 	assert.Contains(t, contentStr, "devkit-devnet-l1:")
 	assert.Contains(t, contentStr, "devkit-devnet-l2:")
+	assert.Contains(t, contentStr, "devkit-devnet-l2-op:")
+	assert.Contains(t, contentStr, "- 9546:8545")
+
+	// The rendered file is valid yaml with a service per chain
+	var compose struct {
+		Services map[string]struct {
+			ContainerName string            `yaml:"container_name"`
+			Command       []string          `yaml:"command"`
+			Labels        map[string]string `yaml:"labels"`
+		} `yaml:"services"`
+	}
+	require.NoError(t, yaml.Unmarshal(content, &compose))
+	assert.Len(t, compose.Services, 3)
+	assert.Equal(t, "devkit-devnet-l2-op-test-project", compose.Services["devkit-devnet-l2-op"].ContainerName)
+	assert.Equal(t, "l2-op", compose.Services["devkit-devnet-l2-op"].Labels["devkit.devnet.chain"])
+	assert.Equal(t, []string{"--host", "0.0.0.0", "--chain-id", "31339"}, compose.Services["devkit-devnet-l2-op"].Command)
 }
 
 // TestDevnetRPCURLGeneration tests RPC URL generation
@@ -206,13 +233,13 @@ func TestDevnetRPCURLGeneration(t *testing.T) {
 
 // TestDevnetContainerNames tests container name generation
 func TestDevnetContainerNames(t *testing.T) {
-	projectName := "test-project"
+	namespace := devnet.Namespace("test-project", devnet.DEVNET_CONTEXT)
 
-	l1ContainerName := "devkit-devnet-l1-" + projectName
-	l2ContainerName := "devkit-devnet-l2-" + projectName
+	l1ContainerName := devnet.ChainContainerName(common.L1, namespace)
+	l2ContainerName := devnet.ChainContainerName(common.L2, namespace)
 
-	assert.Equal(t, "devkit-devnet-l1-test-project", l1ContainerName)
-	assert.Equal(t, "devkit-devnet-l2-test-project", l2ContainerName)
+	assert.Equal(t, "devkit-devnet-l1-test-project-devnet", l1ContainerName)
+	assert.Equal(t, "devkit-devnet-l2-test-project-devnet", l2ContainerName)
 }
 
 // TestDevnetStopCommand tests the stop command logic
@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/urfave/cli/v2"
+)
+
+// timeChainFlag restricts a time command to some of the devnet chains
+var timeChainFlag = &cli.StringSliceFlag{
+	Name:  "chain",
+	Usage: "Only act on the named chain (l1, l2, ...), can be repeated. Defaults to every chain",
+}
+
+// timeSyncFlag keeps the chains' clocks aligned after mining
+var timeSyncFlag = &cli.BoolFlag{
+	Name:  "sync",
+	Usage: "Warp the other chains forward afterwards so every chain shares the same timestamp",
+	Value: true,
+}
+
+// timeCommand groups the chain time travel subcommands under `devkit avs devnet time`
+var timeCommand = &cli.Command{
+	Name:  "time",
+	Usage: "Inspect and move the devnet chains' clocks (mine blocks, warp to a timestamp, sync L1/L2)",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "show",
+			Usage: "Show each chain's head block and timestamp",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+				},
+				timeChainFlag,
+			}, common.GlobalFlags...),
+			Action: ShowDevnetTimeAction,
+		},
+		{
+			Name:      "mine",
+			Usage:     "Mine blocks on the devnet chains",
+			ArgsUsage: "<blocks>",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+				},
+				timeChainFlag,
+				timeSyncFlag,
+			}, common.GlobalFlags...),
+			Action: MineDevnetBlocksAction,
+		},
+		{
+			Name:  "warp",
+			Usage: "Move the devnet chains forward to a timestamp or by a duration, e.g. past an allocation delay or upgrade-by-time",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+				},
+				timeChainFlag,
+				&cli.StringFlag{
+					Name:  "to",
+					Usage: "Target timestamp as unix seconds or RFC3339",
+				},
+				&cli.DurationFlag{
+					Name:  "by",
+					Usage: "Advance by a duration (e.g. 1h30m) from the most advanced chain's timestamp",
+				},
+			}, common.GlobalFlags...),
+			Action: WarpDevnetTimeAction,
+		},
+		{
+			Name:  "sync",
+			Usage: "Warp the chains behind forward to the most advanced chain's timestamp",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "context",
+					Usage: "Select the context to use in this command (devnet, testnet or mainnet)",
+				},
+				timeChainFlag,
+			}, common.GlobalFlags...),
+			Action: SyncDevnetTimeAction,
+		},
+	},
+}
+
+// ShowDevnetTimeAction prints the head block and timestamp of each chain
+func ShowDevnetTimeAction(cCtx *cli.Context) error {
+	chains, closeChains, err := dialDevnetTimeChains(cCtx)
+	if err != nil {
+		return err
+	}
+	defer closeChains()
+
+	return printChainTimes(cCtx, chains)
+}
+
+// MineDevnetBlocksAction mines the given number of blocks on each selected chain
+func MineDevnetBlocksAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	numBlocks, err := strconv.ParseUint(cCtx.Args().First(), 10, 64)
+	if err != nil || numBlocks == 0 {
+		return fmt.Errorf("number of blocks is required: devkit avs devnet time mine <blocks>")
+	}
+
+	chains, closeChains, err := dialDevnetTimeChains(cCtx)
+	if err != nil {
+		return err
+	}
+	defer closeChains()
+
+	for _, chain := range chains {
+		logger.Info("Mining %d blocks on %s", numBlocks, chain.Name)
+		if err := devnet.AdvanceBlocks(cCtx.Context, chain, numBlocks); err != nil {
+			return err
+		}
+	}
+	if cCtx.Bool(timeSyncFlag.Name) && len(chains) > 1 {
+		if err := devnet.SyncL1L2Timestamps(cCtx.Context, chains); err != nil {
+			return fmt.Errorf("failed to sync chain timestamps: %w", err)
+		}
+	}
+
+	return printChainTimes(cCtx, chains)
+}
+
+// WarpDevnetTimeAction mines a block stamped with the target time on each selected chain
+func WarpDevnetTimeAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx.Context)
+
+	to, by := cCtx.String("to"), cCtx.Duration("by")
+	if (to == "") == (by == 0) {
+		return fmt.Errorf("exactly one of --to or --by is required")
+	}
+	if by < 0 {
+		return fmt.Errorf("--by must be positive, chains cannot travel back in time")
+	}
+
+	chains, closeChains, err := dialDevnetTimeChains(cCtx)
+	if err != nil {
+		return err
+	}
+	defer closeChains()
+
+	// Read every head before mining so a bad target never leaves the devnet half warped
+	var latest uint64
+	for _, chain := range chains {
+		head, err := devnet.GetTimestamp(cCtx.Context, chain)
+		if err != nil {
+			return err
+		}
+		latest = max(latest, head.Timestamp)
+	}
+
+	// Advance from the most advanced chain so every chain lands on the same timestamp
+	target := latest + uint64(by/time.Second)
+	if to != "" {
+		if target, err = parseWarpTimestamp(to); err != nil {
+			return err
+		}
+	}
+	if target <= latest {
+		return fmt.Errorf("cannot warp to %d, the devnet chains are already at %d", target, latest)
+	}
+
+	for _, chain := range chains {
+		logger.Info("Warping %s to %d (%s)", chain.Name, target, time.Unix(int64(target), 0).UTC().Format(time.RFC3339))
+		if err := devnet.AdvanceBlocksToTS(cCtx.Context, chain, target); err != nil {
+			return err
+		}
+	}
+
+	return printChainTimes(cCtx, chains)
+}
+
+// SyncDevnetTimeAction aligns the selected chains on the most advanced chain's timestamp
+func SyncDevnetTimeAction(cCtx *cli.Context) error {
+	chains, closeChains, err := dialDevnetTimeChains(cCtx)
+	if err != nil {
+		return err
+	}
+	defer closeChains()
+
+	if err := devnet.SyncL1L2Timestamps(cCtx.Context, chains); err != nil {
+		return fmt.Errorf("failed to sync chain timestamps: %w", err)
+	}
+	return printChainTimes(cCtx, chains)
+}
+
+// parseWarpTimestamp accepts unix seconds or an RFC3339 time
+func parseWarpTimestamp(value string) (uint64, error) {
+	if ts, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return ts, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --to %q: use unix seconds or RFC3339 (e.g. 2025-01-01T00:00:00Z)", value)
+	}
+	if t.Unix() < 0 {
+		return 0, fmt.Errorf("invalid --to %q: must be after the unix epoch", value)
+	}
+	return uint64(t.Unix()), nil
+}
+
+// dialDevnetTimeChains connects to the devnet chains selected by --chain, l1 first
+func dialDevnetTimeChains(cCtx *cli.Context) ([]devnet.TimeClient, func(), error) {
+	// Load config for selected context
+	contextName := cCtx.String("context")
+	var cfg *common.ConfigWithContextConfig
+	var err error
+	if contextName == "" {
+		cfg, contextName, err = common.LoadDefaultConfigWithContextConfig()
+	} else {
+		cfg, contextName, err = common.LoadConfigWithContextConfig(contextName)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configurations: %w", err)
+	}
+	if contextName != devnet.DEVNET_CONTEXT {
+		return nil, nil, fmt.Errorf("time travel is only available on devnet - please run with `--context devnet`")
+	}
+
+	// Extract context details
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return nil, nil, fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+
+	if err := applyRecordedRPCURLs(envCtx, contextName); err != nil {
+		return nil, nil, err
+	}
+
+	names, err := selectTimeChains(envCtx, cCtx.StringSlice(timeChainFlag.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chains := make([]devnet.TimeClient, 0, len(names))
+	closeChains := func() {
+		for _, chain := range chains {
+			chain.Client.Close()
+		}
+	}
+	for _, name := range names {
+		backend, err := devnet.ChainBackendFor(name, envCtx.Chains[name])
+		if err != nil {
+			closeChains()
+			return nil, nil, err
+		}
+		chain, err := devnet.DialTimeClient(cCtx.Context, name, backend, envCtx.Chains[name].RPCURL)
+		if err != nil {
+			closeChains()
+			return nil, nil, err
+		}
+		chains = append(chains, chain)
+	}
+	return chains, closeChains, nil
+}
+
+// selectTimeChains returns the chains named by --chain in devnet order, or every chain when none are named
+func selectTimeChains(envCtx common.ChainContextConfig, selected []string) ([]string, error) {
+	all := append([]string{common.L1}, common.L2ChainNames(envCtx.Chains)...)
+	if _, ok := envCtx.Chains[common.L1]; !ok {
+		return nil, fmt.Errorf("failed to find a chain with name: l1 in devnet.yaml")
+	}
+	if len(selected) == 0 {
+		return all, nil
+	}
+
+	for _, name := range selected {
+		if _, ok := envCtx.Chains[name]; !ok {
+			return nil, fmt.Errorf("unknown chain %q, expected one of %v", name, all)
+		}
+	}
+	names := make([]string, 0, len(selected))
+	for _, name := range all {
+		if slices.Contains(selected, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func printChainTimes(cCtx *cli.Context, chains []devnet.TimeClient) error {
+	for _, chain := range chains {
+		head, err := devnet.GetTimestamp(cCtx.Context, chain)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cCtx.App.Writer, "%s%-8s%s block %d, timestamp %d (%s)\n",
+			devnet.Cyan, head.Name, devnet.Reset,
+			head.Block, head.Timestamp, time.Unix(int64(head.Timestamp), 0).UTC().Format(time.RFC3339),
+		)
+	}
+	return nil
+}
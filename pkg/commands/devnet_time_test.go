@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectTimeChains(t *testing.T) {
+	envCtx := common.ChainContextConfig{Chains: map[string]common.ChainConfig{
+		"l1": {}, "l2": {}, "l2-op": {},
+	}}
+
+	names, err := selectTimeChains(envCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"l1", "l2", "l2-op"}, names)
+
+	// Selection keeps devnet order
+	names, err = selectTimeChains(envCtx, []string{"l2-op", "l1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"l1", "l2-op"}, names)
+
+	_, err = selectTimeChains(envCtx, []string{"l3"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown chain "l3"`)
+}
+
+func TestParseWarpTimestamp(t *testing.T) {
+	ts, err := parseWarpTimestamp("1735689600")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1735689600), ts)
+
+	ts, err = parseWarpTimestamp("2025-01-01T00:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1735689600), ts)
+
+	_, err = parseWarpTimestamp("tomorrow")
+	assert.Error(t, err)
+}
@@ -387,6 +387,7 @@ func publishReleaseToReleaseManagerAction(
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 	logger.Info("Publishing operator set mapping from script output...")
 	err = contractCaller.PublishRelease(ctx, ethcommon.HexToAddress(avs), artifacts, operatorSetId, uint32(upgradeByTime))
 	if err != nil {
@@ -480,6 +481,7 @@ func setReleaseMetadataURIAction(cCtx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.UseTxManager(newContextTxManager(client, envCtx, logger))
 
 	// Set release metadata URI
 	err = contractCaller.SetReleaseMetadata(
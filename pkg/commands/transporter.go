@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"slices"
 	"strconv"
 	"time"
 
@@ -152,25 +153,32 @@ func Transport(cCtx *cli.Context) error {
 	// Get the values from env/config
 	crossChainRegistryAddress := ethcommon.HexToAddress(envCtx.EigenLayer.L1.CrossChainRegistry)
 
-	// Unpack chain config from context
-	l1Config, ok := envCtx.Chains[common.L1]
-	if !ok {
-		return fmt.Errorf("L1 chain config not found in context ('%s')", contextName)
-	}
-	l2Config, ok := envCtx.Chains[common.L2]
-	if !ok {
-		return fmt.Errorf("L2 chain config not found in context ('%s')", contextName)
+	// Reach the devnet on the ports recorded when it was started
+	if err := applyRecordedRPCURLs(envCtx, contextName); err != nil {
+		return err
 	}
 
-	// Unpack chain details from chain configs
+	// Register the L1 and every L2 from context with the chain manager
+	cm := chainManager.NewChainManager()
+	l1Config, l2Configs, err := addContextChains(cm, envCtx, contextName)
+	if err != nil {
+		return err
+	}
 	l1RpcUrl := l1Config.RPCURL
-	l2RpcUrl := l2Config.RPCURL
-	l1ChainId := l1Config.ChainID
-	l2ChainId := l2Config.ChainID
+
+	l1Backend, err := devnet.ChainBackendFor(common.L1, l1Config)
+	if err != nil {
+		return err
+	}
 
 	// Attempt to advance blocks
 	if contextName == devnet.DEVNET_CONTEXT {
-		err = devnet.AdvanceBlocks(cCtx, l1RpcUrl, 100)
+		l1, err := devnet.DialTimeClient(cCtx.Context, common.L1, l1Backend, l1RpcUrl)
+		if err != nil {
+			return err
+		}
+		err = devnet.AdvanceBlocks(cCtx.Context, l1, 100)
+		l1.Client.Close()
 		if err != nil {
 			return fmt.Errorf("failed to advance blocks: %v", err)
 		}
@@ -179,24 +187,7 @@ func Transport(cCtx *cli.Context) error {
 		time.Sleep(12 * time.Second)
 	}
 
-	cm := chainManager.NewChainManager()
-
-	l1ChainManagerConfig := &chainManager.ChainConfig{
-		ChainID: uint64(l1ChainId),
-		RPCUrl:  l1RpcUrl,
-	}
-	l2ChainManagerConfig := &chainManager.ChainConfig{
-		ChainID: uint64(l2ChainId),
-		RPCUrl:  l2RpcUrl,
-	}
-	if err := cm.AddChain(l1ChainManagerConfig); err != nil {
-		return fmt.Errorf("failed to add l1 chain: %v", err)
-	}
-	if err := cm.AddChain(l2ChainManagerConfig); err != nil {
-		return fmt.Errorf("failed to add l2 chain: %v", err)
-	}
-
-	l1Client, err := cm.GetChainForId(l1ChainManagerConfig.ChainID)
+	l1Client, err := cm.GetChainForId(uint64(l1Config.ChainID))
 	if err != nil {
 		return fmt.Errorf("failed to get l1 chain for ID %d: %v", l1Config.ChainID, err)
 	}
@@ -221,8 +212,7 @@ func Transport(cCtx *cli.Context) error {
 	// Sync chains so that timestamps match on both anvil instances (for devnet)
 	if contextName == devnet.DEVNET_CONTEXT {
 		logger.Info("Syncing chains...")
-		err = devnet.SyncL1L2Timestamps(cCtx, l1RpcUrl, l2RpcUrl)
-		if err != nil {
+		if err := syncDevnetChainTimes(cCtx, l1Backend, l1RpcUrl, envCtx.Chains); err != nil {
 			return fmt.Errorf("failed to sync chains: %v", err)
 		}
 	}
@@ -273,10 +263,16 @@ func Transport(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to create transport: %v", err)
 	}
 
-	// Provide chainIds to ignore for Devnets
+	// Provide chainIds to ignore for Devnets (the forked chains are registered but not served locally)
 	var ignoreChainIds = []*big.Int{}
 	if contextName == devnet.DEVNET_CONTEXT {
-		ignoreChainIds = []*big.Int{new(big.Int).SetUint64(11155111), new(big.Int).SetUint64(84532)}
+		forkedChainIds, err := devnetForkedChainIDs(cCtx.Context, cfg, contextName)
+		if err != nil {
+			return err
+		}
+		for _, chainId := range forkedChainIds {
+			ignoreChainIds = append(ignoreChainIds, new(big.Int).SetUint64(chainId))
+		}
 	}
 
 	// Transport globalTableRoot
@@ -292,8 +288,10 @@ func Transport(cCtx *cli.Context) error {
 	}
 
 	// Collect the provided roots
-	roots[l1ChainManagerConfig.ChainID] = root
-	roots[l2ChainManagerConfig.ChainID] = root
+	roots[uint64(l1Config.ChainID)] = root
+	for _, l2Config := range l2Configs {
+		roots[uint64(l2Config.ChainID)] = root
+	}
 	// Write the roots to context (each time we process one)
 	err = WriteStakeTableRootsToContext(cCtx, roots)
 	if err != nil {
@@ -471,47 +469,21 @@ func GetOnchainStakeTableRoots(cCtx *cli.Context) (map[uint64][32]byte, error) {
 	// Get the values from env/config
 	crossChainRegistryAddress := ethcommon.HexToAddress(envCtx.EigenLayer.L1.CrossChainRegistry)
 
-	// Unpack chain config from context
-	l1Config, ok := envCtx.Chains[common.L1]
-	if !ok {
-		return nil, fmt.Errorf("L1 chain config not found in context ('%s')", contextName)
-	}
-	l2Config, ok := envCtx.Chains[common.L2]
-	if !ok {
-		return nil, fmt.Errorf("L2 chain config not found in context ('%s')", contextName)
+	// Reach the devnet on the ports recorded when it was started
+	if err := applyRecordedRPCURLs(envCtx, contextName); err != nil {
+		return nil, err
 	}
 
-	// Unpack chain details from chain configs
-	l1RpcUrl := l1Config.RPCURL
-	l2RpcUrl := l2Config.RPCURL
-	l1ChainId := l1Config.ChainID
-	l2ChainId := l2Config.ChainID
-
-	// Get a new chainManager
+	// Register the L1 and every L2 from context with the chain manager
 	cm := chainManager.NewChainManager()
-
-	// Configure L1 chain
-	l1ChainManagerConfig := &chainManager.ChainConfig{
-		ChainID: uint64(l1ChainId),
-		RPCUrl:  l1RpcUrl,
-	}
-
-	// Configure L2 chain
-	l2ChainManagerConfig := &chainManager.ChainConfig{
-		ChainID: uint64(l2ChainId),
-		RPCUrl:  l2RpcUrl,
-	}
-
-	if err := cm.AddChain(l1ChainManagerConfig); err != nil {
-		return nil, fmt.Errorf("failed to add l1 chain: %v", err)
-	}
-	if err := cm.AddChain(l2ChainManagerConfig); err != nil {
-		return nil, fmt.Errorf("failed to add l2 chain: %v", err)
+	l1Config, _, err := addContextChains(cm, envCtx, contextName)
+	if err != nil {
+		return nil, err
 	}
 
-	l1Client, err := cm.GetChainForId(l1ChainManagerConfig.ChainID)
+	l1Client, err := cm.GetChainForId(uint64(l1Config.ChainID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chain for ID %d: %v", l1ChainManagerConfig.ChainID, err)
+		return nil, fmt.Errorf("failed to get chain for ID %d: %v", l1Config.ChainID, err)
 	}
 
 	// Construct registry caller
@@ -529,10 +501,17 @@ func GetOnchainStakeTableRoots(cCtx *cli.Context) (map[uint64][32]byte, error) {
 		return nil, fmt.Errorf("no supported chains found in cross-chain registry")
 	}
 
+	// Ignore the forked chains if checking devnet
+	var ignoreChainIds []uint64
+	if contextName == devnet.DEVNET_CONTEXT {
+		if ignoreChainIds, err = devnetForkedChainIDs(cCtx.Context, cfg, contextName); err != nil {
+			return nil, err
+		}
+	}
+
 	// Iterate and collect all roots for all chainIds
 	for i, chainId := range chainIds {
-		// Ignore 11155111 and 84532 from chainIds if checking devnet
-		if contextName == devnet.DEVNET_CONTEXT && (chainId.Uint64() == 11155111 || chainId.Uint64() == 84532) {
+		if slices.Contains(ignoreChainIds, chainId.Uint64()) {
 			continue
 		}
 
@@ -704,3 +683,56 @@ func ScheduleTransportWithParserAndFunc(cCtx *cli.Context, cronExpr string, pars
 	log.Println("Transport scheduler stopped.")
 	return nil
 }
+
+// addContextChains registers the context's L1 and every L2 with the chain manager, returning their chain configs
+func addContextChains(cm *chainManager.ChainManager, envCtx common.ChainContextConfig, contextName string) (common.ChainConfig, []common.ChainConfig, error) {
+	l1Config, ok := envCtx.Chains[common.L1]
+	if !ok {
+		return common.ChainConfig{}, nil, fmt.Errorf("L1 chain config not found in context ('%s')", contextName)
+	}
+	if err := cm.AddChain(&chainManager.ChainConfig{ChainID: uint64(l1Config.ChainID), RPCUrl: l1Config.RPCURL}); err != nil {
+		return common.ChainConfig{}, nil, fmt.Errorf("failed to add l1 chain: %v", err)
+	}
+
+	l2Names := common.L2ChainNames(envCtx.Chains)
+	if len(l2Names) == 0 {
+		return common.ChainConfig{}, nil, fmt.Errorf("L2 chain config not found in context ('%s')", contextName)
+	}
+	l2Configs := make([]common.ChainConfig, 0, len(l2Names))
+	for _, name := range l2Names {
+		l2Config := envCtx.Chains[name]
+		if err := cm.AddChain(&chainManager.ChainConfig{ChainID: uint64(l2Config.ChainID), RPCUrl: l2Config.RPCURL}); err != nil {
+			return common.ChainConfig{}, nil, fmt.Errorf("failed to add %s chain: %v", name, err)
+		}
+		l2Configs = append(l2Configs, l2Config)
+	}
+	return l1Config, l2Configs, nil
+}
+
+// syncDevnetChainTimes warps the devnet L1 and L2s behind the most advanced chain forward to its timestamp
+func syncDevnetChainTimes(cCtx *cli.Context, l1Backend devnet.ChainBackend, l1RpcUrl string, chainConfigs map[string]common.ChainConfig) error {
+	l1, err := devnet.DialTimeClient(cCtx.Context, "L1", l1Backend, l1RpcUrl)
+	if err != nil {
+		return err
+	}
+	chains := []devnet.TimeClient{l1}
+	defer func() {
+		for _, chain := range chains {
+			chain.Client.Close()
+		}
+	}()
+
+	for _, name := range common.L2ChainNames(chainConfigs) {
+		l2Config := chainConfigs[name]
+		l2Backend, err := devnet.ChainBackendFor(name, l2Config)
+		if err != nil {
+			return err
+		}
+		l2, err := devnet.DialTimeClient(cCtx.Context, fmt.Sprintf("L2 (%d)", l2Config.ChainID), l2Backend, l2Config.RPCURL)
+		if err != nil {
+			return err
+		}
+		chains = append(chains, l2)
+	}
+	return devnet.SyncL1L2Timestamps(cCtx.Context, chains)
+}
@@ -1,13 +1,16 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -33,6 +36,8 @@ type ForkConfig struct {
 	Url       string `json:"url" yaml:"url"`
 	Block     int    `json:"block" yaml:"block"`
 	BlockTime int    `json:"block_time" yaml:"block_time"`
+	// Preset names the public chain forked (sepolia, base-sepolia, ...), see ChainPresets
+	Preset string `json:"preset,omitempty" yaml:"preset,omitempty"`
 }
 
 type OperatorSpec struct {
@@ -113,10 +118,40 @@ type EigenLayerL2Config struct {
 	TaskMailbox              string `json:"task_mailbox" yaml:"task_mailbox"`
 }
 
+// NamedAddresses returns the context field name and address of every L1 contract
+func (c EigenLayerL1Config) NamedAddresses() [][2]string {
+	return [][2]string{
+		{"allocation_manager", c.AllocationManager},
+		{"delegation_manager", c.DelegationManager},
+		{"strategy_manager", c.StrategyManager},
+		{"bn254_table_calculator", c.BN254TableCalculator},
+		{"ecdsa_table_calculator", c.ECDSATableCalculator},
+		{"cross_chain_registry", c.CrossChainRegistry},
+		{"key_registrar", c.KeyRegistrar},
+		{"release_manager", c.ReleaseManager},
+		{"operator_table_updater", c.OperatorTableUpdater},
+		{"task_mailbox", c.TaskMailbox},
+	}
+}
+
+// NamedAddresses returns the context field name and address of every L2 contract
+func (c EigenLayerL2Config) NamedAddresses() [][2]string {
+	return [][2]string{
+		{"bn254_certificate_verifier", c.BN254CertificateVerifier},
+		{"ecdsa_certificate_verifier", c.ECDSACertificateVerifier},
+		{"operator_table_updater", c.OperatorTableUpdater},
+		{"task_mailbox", c.TaskMailbox},
+	}
+}
+
 type ChainConfig struct {
 	ChainID int         `json:"chain_id" yaml:"chain_id"`
 	RPCURL  string      `json:"rpc_url" yaml:"rpc_url"`
 	Fork    *ForkConfig `json:"fork" yaml:"fork"`
+	// Port is the host port the devnet exposes this chain on (only used for L2s beyond the primary l2)
+	Port int `json:"port,omitempty" yaml:"port,omitempty"`
+	// Backend is the node the devnet runs this chain on (anvil or hardhat), defaults to anvil
+	Backend string `json:"backend,omitempty" yaml:"backend,omitempty"`
 }
 
 type DeployedL1Contracts struct {
@@ -207,6 +242,84 @@ type ChainContextConfig struct {
 	OperatorRegistrations []OperatorRegistration `json:"operator_registrations" yaml:"operator_registrations"`
 	Stakers               []StakerSpec           `json:"stakers" yaml:"stakers"`
 	Artifact              *ArtifactConfig        `json:"artifact" yaml:"artifact"`
+	Services              []ServiceConfig        `json:"services,omitempty" yaml:"services,omitempty"`
+	Funding               []FundingAccount       `json:"funding,omitempty" yaml:"funding,omitempty"`
+	Transactions          *TransactionsConfig    `json:"transactions,omitempty" yaml:"transactions,omitempty"`
+}
+
+// TransactionsConfig sets the fees of the context's transactions and how long they may stay pending
+type TransactionsConfig struct {
+	// MaxFeeGwei caps the fee per gas, including fee bumps of stuck transactions
+	MaxFeeGwei float64 `json:"max_fee_gwei,omitempty" yaml:"max_fee_gwei,omitempty"`
+	// PriorityFeeGwei replaces the node's suggested tip
+	PriorityFeeGwei float64 `json:"priority_fee_gwei,omitempty" yaml:"priority_fee_gwei,omitempty"`
+	// GasMultiplier scales estimated gas limits, e.g. 1.2
+	GasMultiplier float64 `json:"gas_multiplier,omitempty" yaml:"gas_multiplier,omitempty"`
+	// ReceiptTimeout is how long a transaction may stay pending before it is replaced with higher fees, e.g. "3m"
+	ReceiptTimeout time.Duration `json:"receipt_timeout,omitempty" yaml:"receipt_timeout,omitempty"`
+	// MaxReplacements bounds the fee bumps of a stuck transaction
+	MaxReplacements int `json:"max_replacements,omitempty" yaml:"max_replacements,omitempty"`
+}
+
+// TxPolicy returns the transaction policy of the context, unset fields keep the DefaultTxPolicy values
+func (c *TransactionsConfig) TxPolicy() TxPolicy {
+	policy := DefaultTxPolicy()
+	if c == nil {
+		return policy
+	}
+	if c.MaxFeeGwei > 0 {
+		policy.MaxFeePerGas = gweiToWei(c.MaxFeeGwei)
+	}
+	if c.PriorityFeeGwei > 0 {
+		policy.PriorityFeePerGas = gweiToWei(c.PriorityFeeGwei)
+	}
+	if c.GasMultiplier > 0 {
+		policy.GasMultiplier = c.GasMultiplier
+	}
+	if c.ReceiptTimeout > 0 {
+		policy.ReceiptTimeout = c.ReceiptTimeout
+	}
+	if c.MaxReplacements > 0 {
+		policy.MaxReplacements = c.MaxReplacements
+	}
+	return policy
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(1e9)).Int(nil)
+	return wei
+}
+
+// FundingAccount declares the ETH and ERC20 balances an account is given on the devnet
+type FundingAccount struct {
+	Address string `json:"address" yaml:"address"`
+	// Chains lists the devnet chains to fund the account on, defaults to l1
+	Chains []string `json:"chains,omitempty" yaml:"chains,omitempty"`
+	// ETH is the minimum ether balance, e.g. "10" or "0.5"
+	ETH    string         `json:"eth,omitempty" yaml:"eth,omitempty"`
+	Tokens []FundingToken `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+}
+
+// FundingToken declares the minimum balance of an ERC20 token and how to obtain it
+type FundingToken struct {
+	Address string `json:"address" yaml:"address"`
+	// Amount is in whole tokens, scaled by the token's decimals()
+	Amount string `json:"amount" yaml:"amount"`
+	// Holder is an account holding the token which is impersonated to transfer it
+	Holder string `json:"holder,omitempty" yaml:"holder,omitempty"`
+	// BalanceSlot is the storage slot of the token's balances mapping, written directly through the chain backend
+	BalanceSlot *uint64 `json:"balance_slot,omitempty" yaml:"balance_slot,omitempty"`
+}
+
+// ServiceConfig is an extra container (database, cache, explorer, ...) started alongside the devnet chains
+type ServiceConfig struct {
+	Name        string            `json:"name" yaml:"name"`
+	Image       string            `json:"image" yaml:"image"`
+	Command     []string          `json:"command,omitempty" yaml:"command,omitempty"`
+	Ports       []string          `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Environment map[string]string `json:"environment,omitempty" yaml:"environment,omitempty"`
+	Volumes     []string          `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	DependsOn   []string          `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
 }
 
 func LoadBaseConfig() (map[string]interface{}, error) {
@@ -266,7 +379,59 @@ func LoadDefaultConfigWithContextConfig() (*ConfigWithContextConfig, string, err
 	return LoadConfigWithContextConfig(contextName)
 }
 
+// contextOverridesKey is the context.Context key of the contexts read from memory instead of ./config/contexts
+type contextOverridesKey struct{}
+
+// WithContextOverride stores rootNode as the yaml of contextName in ctx, so LoadConfigWithContextConfigFromContext
+// reads it instead of the context's yaml file, e.g. during a dry run which leaves the file untouched
+func WithContextOverride(ctx context.Context, contextName string, rootNode *yaml.Node) (context.Context, error) {
+	data, err := yaml.Marshal(rootNode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode context %q: %w", contextName, err)
+	}
+	overrides := map[string][]byte{contextName: data}
+	if outer, ok := ctx.Value(contextOverridesKey{}).(map[string][]byte); ok {
+		for name, data := range outer {
+			if _, ok := overrides[name]; !ok {
+				overrides[name] = data
+			}
+		}
+	}
+	return context.WithValue(ctx, contextOverridesKey{}, overrides), nil
+}
+
+// LoadConfigWithContextConfigFromContext loads the config with contextName, the project's context when empty, reading
+// the context from ctx when it is overridden there, see WithContextOverride
+func LoadConfigWithContextConfigFromContext(ctx context.Context, contextName string) (*ConfigWithContextConfig, string, error) {
+	if contextName == "" {
+		currentConfig, err := LoadBaseConfigYaml()
+		if err != nil {
+			return nil, "", fmt.Errorf("error loading yaml: %w", err)
+		}
+		contextName = currentConfig.Config.Project.Context
+	}
+	if overrides, ok := ctx.Value(contextOverridesKey{}).(map[string][]byte); ok {
+		if ctxData, ok := overrides[contextName]; ok {
+			return LoadConfigWithContextData(contextName, ctxData)
+		}
+	}
+	return LoadConfigWithContextConfig(contextName)
+}
+
 func LoadConfigWithContextConfig(contextName string) (*ConfigWithContextConfig, string, error) {
+	// Load requested context file
+	contextFile := filepath.Join(DefaultConfigWithContextConfigPath, "contexts", contextName+".yaml")
+	ctxData, err := os.ReadFile(contextFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read context %q file: %w", contextName, err)
+	}
+
+	return LoadConfigWithContextData(contextName, ctxData)
+}
+
+// LoadConfigWithContextData loads the base config together with a context given as yaml, e.g. one which is not
+// written to ./config/contexts yet
+func LoadConfigWithContextData(contextName string, ctxData []byte) (*ConfigWithContextConfig, string, error) {
 	// Load base config
 	configPath := filepath.Join(DefaultConfigWithContextConfigPath, BaseConfig)
 	data, err := os.ReadFile(configPath)
@@ -278,13 +443,7 @@ func LoadConfigWithContextConfig(contextName string) (*ConfigWithContextConfig,
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, "", fmt.Errorf("failed to parse base config: %w", err)
 	}
-
-	// Load requested context file
 	contextFile := filepath.Join(DefaultConfigWithContextConfigPath, "contexts", contextName+".yaml")
-	ctxData, err := os.ReadFile(contextFile)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to read context %q file: %w", contextName, err)
-	}
 
 	var wrapper struct {
 		Version string             `yaml:"version"`
@@ -295,6 +454,11 @@ func LoadConfigWithContextConfig(contextName string) (*ConfigWithContextConfig,
 		return nil, "", fmt.Errorf("failed to parse context file %q: %w", contextFile, err)
 	}
 
+	// Fill in what the context leaves to its chains' fork presets
+	if err := ApplyForkPresets(&wrapper.Context); err != nil {
+		return nil, "", fmt.Errorf("invalid fork preset in context %q: %w", contextName, err)
+	}
+
 	cfg.Context = map[string]ChainContextConfig{
 		contextName: wrapper.Context,
 	}
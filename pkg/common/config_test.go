@@ -1,6 +1,7 @@
 package common_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Layr-Labs/devkit-cli/config/configs"
@@ -93,6 +94,66 @@ func TestLoadConfigWithContextConfig_FromCopiedTempFile(t *testing.T) {
 	assert.Equal(t, "0xd9Cb89F1993292dEC2F973934bC63B0f2A702776", cfg.Context["devnet"].EigenLayer.L1.ReleaseManager)
 }
 
+func TestLoadConfigWithContextData(t *testing.T) {
+	origDir, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	assert.NoError(t, os.MkdirAll("config", 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join("config", common.BaseConfig), []byte(configs.ConfigYamls[configs.LatestVersion]), 0644))
+
+	// The context is parsed from memory without a ./config/contexts file
+	cfg, contextName, err := common.LoadConfigWithContextData("devnet", []byte(contexts.ContextYamls[contexts.LatestVersion]))
+	assert.NoError(t, err)
+	assert.Equal(t, "devnet", contextName)
+	assert.Equal(t, "my-avs", cfg.Config.Project.Name)
+	assert.NotEmpty(t, cfg.Context["devnet"].Operators)
+	assert.NoFileExists(t, filepath.Join("config", "contexts", "devnet.yaml"))
+}
+
+func TestWithContextOverride(t *testing.T) {
+	origDir, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	assert.NoError(t, os.MkdirAll(filepath.Join("config", "contexts"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join("config", common.BaseConfig), []byte(configs.ConfigYamls[configs.LatestVersion]), 0644))
+	contextPath := filepath.Join("config", "contexts", "devnet.yaml")
+	assert.NoError(t, os.WriteFile(contextPath, []byte(contexts.ContextYamls[contexts.LatestVersion]), 0644))
+
+	// Change the rpc url in memory only
+	_, rootNode, contextNode, _, err := common.LoadContext("devnet")
+	assert.NoError(t, err)
+	rpcURLNode := common.GetChildByKey(common.GetChildByKey(common.GetChildByKey(contextNode, "chains"), "l1"), "rpc_url")
+	rpcURLNode.Value = "http://override:8545"
+
+	ctx, err := common.WithContextOverride(context.Background(), "devnet", rootNode)
+	assert.NoError(t, err)
+	cfg, contextName, err := common.LoadConfigWithContextConfigFromContext(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "devnet", contextName)
+	assert.Equal(t, "http://override:8545", cfg.Context["devnet"].Chains["l1"].RPCURL)
+
+	// The file is untouched and read by loaders without the override
+	onDisk, err := os.ReadFile(contextPath)
+	assert.NoError(t, err)
+	assert.Equal(t, contexts.ContextYamls[contexts.LatestVersion], onDisk)
+	for _, load := range []func() (*common.ConfigWithContextConfig, string, error){
+		func() (*common.ConfigWithContextConfig, string, error) {
+			return common.LoadConfigWithContextConfigFromContext(context.Background(), "devnet")
+		},
+		func() (*common.ConfigWithContextConfig, string, error) {
+			return common.LoadConfigWithContextConfig("devnet")
+		},
+	} {
+		cfg, _, err = load()
+		assert.NoError(t, err)
+		assert.NotEqual(t, "http://override:8545", cfg.Context["devnet"].Chains["l1"].RPCURL)
+	}
+}
+
 func LoadConfigWithContextConfigFromPath(contextName string, config_directory_path string) (*common.ConfigWithContextConfig, error) {
 	// Load base config
 	data, err := os.ReadFile(filepath.Join(config_directory_path, common.BaseConfig))
@@ -40,6 +40,7 @@ type ContractCaller struct {
 	crossChainRegistryAddr common.Address
 	releaseManagerAddr     common.Address
 	certVerifier           common.Address
+	txManager              *TxManager
 }
 
 func NewContractCaller(privateKeyHex string, chainID *big.Int, client *ethclient.Client, allocationManagerAddr, delegationManagerAddr, strategyManagerAddr, keyRegistrarAddr, crossChainRegistryAddr, releaseManagerAddr, certVerifierAddr common.Address, logger iface.Logger) (*ContractCaller, error) {
@@ -57,7 +58,7 @@ func NewContractCaller(privateKeyHex string, chainID *big.Int, client *ethclient
 
 	registry := builder.Build()
 
-	return &ContractCaller{
+	cc := &ContractCaller{
 		registry:               registry,
 		ethclient:              client,
 		privateKey:             privateKey,
@@ -70,7 +71,28 @@ func NewContractCaller(privateKeyHex string, chainID *big.Int, client *ethclient
 		crossChainRegistryAddr: crossChainRegistryAddr,
 		releaseManagerAddr:     releaseManagerAddr,
 		certVerifier:           certVerifierAddr,
-	}, nil
+	}
+	cc.UseTxManager(NewTxManager(client, logger))
+	return cc, nil
+}
+
+// UseTxManager sends the caller's transactions through m, so the callers of an action can share one TxManager that
+// tracks every signer's nonces and is configured from the context once
+func (cc *ContractCaller) UseTxManager(m *TxManager) {
+	// Prefer the errors of the contract a transaction called when core contracts share an error name
+	for name, address := range map[string]common.Address{
+		"AllocationManager":  cc.allocationManagerAddr,
+		"DelegationManager":  cc.delegationManagerAddr,
+		"KeyRegistrar":       cc.keyRegistrarAddr,
+		"ReleaseManager":     cc.releaseManagerAddr,
+		"CrossChainRegistry": cc.crossChainRegistryAddr,
+	} {
+		// Callers sharing m may only know some of the core contracts
+		if address != (common.Address{}) {
+			m.reverts.SetAddress(name, address)
+		}
+	}
+	cc.txManager = m
 }
 
 func (cc *ContractCaller) buildTxOpts() (*bind.TransactOpts, error) {
@@ -78,45 +100,42 @@ func (cc *ContractCaller) buildTxOpts() (*bind.TransactOpts, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transactor: %w", err)
 	}
+	// The TxManager prices and sends the signed transaction
+	opts.NoSend = true
 	return opts, nil
 }
 
+// SendAndWaitForTransaction has fn build the transaction with the caller's signing options, whose nonce the caller's
+// TxManager assigns, then sends it through the TxManager and waits for it to be mined
 func (cc *ContractCaller) SendAndWaitForTransaction(
 	ctx context.Context,
 	txDescription string,
-	fn func() (*types.Transaction, error),
+	fn func(opts *bind.TransactOpts) (*types.Transaction, error),
 ) error {
-
-	tx, err := fn()
+	opts, err := cc.buildTxOpts()
 	if err != nil {
-		cc.logger.Error("%s failed during execution: %v", txDescription, err)
-		return fmt.Errorf("%s execution: %w", txDescription, err)
+		return fmt.Errorf("failed to build transaction options: %w", err)
 	}
+	return cc.sendAndWait(ctx, TxRequest{Description: txDescription, Opts: opts, Send: fn})
+}
 
-	receipt, err := bind.WaitMined(ctx, cc.ethclient, tx)
-	if err != nil {
-		cc.logger.Error("Waiting for %s transaction (hash: %s) failed: %v", txDescription, tx.Hash().Hex(), err)
-		return fmt.Errorf("waiting for %s transaction (hash: %s): %w", txDescription, tx.Hash().Hex(), err)
-	}
-	if receipt.Status == 0 {
-		cc.logger.Error("%s transaction (hash: %s) reverted", txDescription, tx.Hash().Hex())
-		return fmt.Errorf("%s transaction (hash: %s) reverted", txDescription, tx.Hash().Hex())
+func (cc *ContractCaller) sendAndWait(ctx context.Context, req TxRequest) error {
+	result := cc.txManager.Send(ctx, req)
+	if result.Err != nil {
+		cc.logger.Error("%v", result.Err)
+		return result.Err
 	}
 	return nil
 }
 
 func (cc *ContractCaller) UpdateAVSMetadata(ctx context.Context, avsAddress common.Address, metadataURI string) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 
 	allocationManager, err := cc.registry.GetAllocationManager(cc.allocationManagerAddr)
 	if err != nil {
 		return fmt.Errorf("failed to get AllocationManager: %w", err)
 	}
 
-	err = cc.SendAndWaitForTransaction(ctx, "UpdateAVSMetadataURI", func() (*types.Transaction, error) {
+	err = cc.SendAndWaitForTransaction(ctx, "UpdateAVSMetadataURI", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := allocationManager.UpdateAVSMetadataURI(opts, avsAddress, metadataURI)
 		if err == nil && tx != nil {
 			cc.logger.Debug(
@@ -136,17 +155,22 @@ func (cc *ContractCaller) UpdateAVSMetadata(ctx context.Context, avsAddress comm
 
 // SetAVSRegistrar sets the registrar address for an AVS
 func (cc *ContractCaller) SetAVSRegistrar(ctx context.Context, avsAddress, registrarAddress common.Address) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 
 	allocationManager, err := cc.registry.GetAllocationManager(cc.allocationManagerAddr)
 	if err != nil {
 		return fmt.Errorf("failed to get AllocationManager: %w", err)
 	}
 
-	err = cc.SendAndWaitForTransaction(ctx, "SetAVSRegistrar", func() (*types.Transaction, error) {
+	currentRegistrar, err := allocationManager.GetAVSRegistrar(&bind.CallOpts{Context: ctx}, avsAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get registrar of AVS %s: %w", avsAddress.Hex(), err)
+	}
+	if currentRegistrar == registrarAddress {
+		cc.logger.Info("AVS registrar already set to %s, skipping", registrarAddress.Hex())
+		return nil
+	}
+
+	err = cc.SendAndWaitForTransaction(ctx, "SetAVSRegistrar", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := allocationManager.SetAVSRegistrar(opts, avsAddress, registrarAddress)
 		if err == nil && tx != nil {
 			cc.logger.Debug(
@@ -165,10 +189,6 @@ func (cc *ContractCaller) SetAVSRegistrar(ctx context.Context, avsAddress, regis
 }
 
 func (cc *ContractCaller) CreateOperatorSets(ctx context.Context, avsAddress common.Address, createSetParams []allocationmanager.IAllocationManagerTypesCreateSetParams) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 
 	allocationManager, err := cc.registry.GetAllocationManager(cc.allocationManagerAddr)
 	if err != nil {
@@ -188,6 +208,9 @@ func (cc *ContractCaller) CreateOperatorSets(ctx context.Context, avsAddress com
 		}
 		if exists {
 			cc.logger.Info("Operator set %d already exists, skipping", param.OperatorSetId)
+			if err := cc.addMissingStrategies(ctx, allocationManager, opSet, param.Strategies); err != nil {
+				return err
+			}
 			continue
 		}
 		filteredParams = append(filteredParams, param)
@@ -198,7 +221,7 @@ func (cc *ContractCaller) CreateOperatorSets(ctx context.Context, avsAddress com
 		return nil
 	}
 
-	err = cc.SendAndWaitForTransaction(ctx, "CreateOperatorSets", func() (*types.Transaction, error) {
+	err = cc.SendAndWaitForTransaction(ctx, "CreateOperatorSets", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := allocationManager.CreateOperatorSets(opts, avsAddress, filteredParams)
 		if err == nil && tx != nil {
 			cc.logger.Debug(
@@ -216,57 +239,121 @@ func (cc *ContractCaller) CreateOperatorSets(ctx context.Context, avsAddress com
 	return err
 }
 
+// addMissingStrategies adds the strategies an existing operator set is missing
+func (cc *ContractCaller) addMissingStrategies(ctx context.Context, allocationManager *allocationmanager.AllocationManager, opSet allocationmanager.OperatorSet, strategies []common.Address) error {
+	onChain, err := allocationManager.GetStrategiesInOperatorSet(&bind.CallOpts{Context: ctx}, opSet)
+	if err != nil {
+		return fmt.Errorf("failed to get strategies of operator set %d: %w", opSet.Id, err)
+	}
+	present := make(map[common.Address]bool, len(onChain))
+	for _, strategy := range onChain {
+		present[strategy] = true
+	}
+	var missing []common.Address
+	for _, strategy := range strategies {
+		if !present[strategy] {
+			missing = append(missing, strategy)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	cc.logger.Info("Adding %d missing strategies to operator set %d", len(missing), opSet.Id)
+	return cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("AddStrategiesToOperatorSet %d", opSet.Id), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return allocationManager.AddStrategiesToOperatorSet(opts, opSet.Avs, opSet.Id, missing)
+	})
+}
+
 func (cc *ContractCaller) RegisterAsOperator(ctx context.Context, operatorAddress common.Address, allocationDelay uint32, metadataURI string) error {
+	req, err := cc.RegisterAsOperatorTx(operatorAddress, allocationDelay, metadataURI)
+	if err != nil || req == nil {
+		return err
+	}
+	return cc.sendAndWait(ctx, *req)
+}
+
+// RegisterAsOperatorTx returns the RegisterAsOperator transaction for a TxManager batch, nil when the operator is already registered
+func (cc *ContractCaller) RegisterAsOperatorTx(operatorAddress common.Address, allocationDelay uint32, metadataURI string) (*TxRequest, error) {
 	opts, err := cc.buildTxOpts()
 	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
 	}
 
 	delegationManager, err := cc.registry.GetDelegationManager(cc.delegationManagerAddr)
 	if err != nil {
-		return fmt.Errorf("failed to get DelegationManager: %w", err)
+		return nil, fmt.Errorf("failed to get DelegationManager: %w", err)
 	}
 
 	exists, err := delegationManager.IsOperator(nil, operatorAddress)
 	if err != nil {
-		return fmt.Errorf("failed to check operator exists %d: %w", operatorAddress, err)
+		return nil, fmt.Errorf("failed to check operator exists %d: %w", operatorAddress, err)
 	}
 
 	if exists {
 		cc.logger.Info("Operator '%s' already registered, skipping", operatorAddress)
-		return nil
-	}
-
-	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("RegisterAsOperator for %s", operatorAddress.Hex()), func() (*types.Transaction, error) {
-		tx, err := delegationManager.RegisterAsOperator(opts, operatorAddress, allocationDelay, metadataURI)
-		if err == nil && tx != nil {
-			cc.logger.Debug(
-				"Transaction hash for RegisterAsOperator: %s\n"+
-					"operatorAddress: %s\n"+
-					"allocationDelay: %d\n"+
-					"metadataURI: %s",
-				tx.Hash().Hex(),
-				operatorAddress,
-				allocationDelay,
-				metadataURI,
-			)
-		}
-		return tx, err
-	})
-
-	return err
+		return nil, nil
+	}
+
+	return &TxRequest{
+		Description: fmt.Sprintf("RegisterAsOperator for %s", operatorAddress.Hex()),
+		Opts:        opts,
+		Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			tx, err := delegationManager.RegisterAsOperator(opts, operatorAddress, allocationDelay, metadataURI)
+			if err == nil && tx != nil {
+				cc.logger.Debug(
+					"Transaction hash for RegisterAsOperator: %s\n"+
+						"operatorAddress: %s\n"+
+						"allocationDelay: %d\n"+
+						"metadataURI: %s",
+					tx.Hash().Hex(),
+					operatorAddress,
+					allocationDelay,
+					metadataURI,
+				)
+			}
+			return tx, err
+		},
+	}, nil
 }
 
 func (cc *ContractCaller) RegisterForOperatorSets(ctx context.Context, operatorAddress, avsAddress common.Address, operatorSetIDs []uint32, payload []byte) error {
+	req, err := cc.RegisterForOperatorSetsTx(ctx, operatorAddress, avsAddress, operatorSetIDs, payload)
+	if err != nil || req == nil {
+		return err
+	}
+	return cc.sendAndWait(ctx, *req)
+}
+
+// RegisterForOperatorSetsTx returns the RegisterForOperatorSets transaction for a TxManager batch, nil when the operator is already a member of every operator set
+func (cc *ContractCaller) RegisterForOperatorSetsTx(ctx context.Context, operatorAddress, avsAddress common.Address, operatorSetIDs []uint32, payload []byte) (*TxRequest, error) {
 	opts, err := cc.buildTxOpts()
 	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
 	}
 
 	allocationManager, err := cc.registry.GetAllocationManager(cc.allocationManagerAddr)
 	if err != nil {
-		return fmt.Errorf("failed to get AllocationManager: %w", err)
+		return nil, fmt.Errorf("failed to get AllocationManager: %w", err)
+	}
+
+	// Only register for the operator sets the operator is not a member of yet
+	var pendingIDs []uint32
+	for _, id := range operatorSetIDs {
+		member, err := allocationManager.IsMemberOfOperatorSet(&bind.CallOpts{Context: ctx}, operatorAddress, allocationmanager.OperatorSet{Avs: avsAddress, Id: id})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check registration of %s to operator set %d: %w", operatorAddress.Hex(), id, err)
+		}
+		if member {
+			cc.logger.Info("Operator %s already registered to operator set %d, skipping", operatorAddress.Hex(), id)
+			continue
+		}
+		pendingIDs = append(pendingIDs, id)
+	}
+	if len(pendingIDs) == 0 {
+		return nil, nil
 	}
+	operatorSetIDs = pendingIDs
 
 	params := allocationmanager.IAllocationManagerTypesRegisterParams{
 		Avs:            avsAddress,
@@ -274,32 +361,31 @@ func (cc *ContractCaller) RegisterForOperatorSets(ctx context.Context, operatorA
 		Data:           payload,
 	}
 
-	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("RegisterForOperatorSets for %s", operatorAddress.Hex()), func() (*types.Transaction, error) {
-		tx, err := allocationManager.RegisterForOperatorSets(opts, operatorAddress, params)
-		if err == nil && tx != nil {
-			cc.logger.Debug(
-				"Transaction hash for RegisterForOperatorSets: %s\n"+
-					"  operatorAddress: %s\n"+
-					"  avsAddress: %s\n"+
-					"  operatorSetIDs: %v\n"+
-					"  payload: %v\n",
-				tx.Hash().Hex(),
-				operatorAddress.Hex(),
-				avsAddress.Hex(),
-				operatorSetIDs,
-				"0x"+hex.EncodeToString(payload),
-			)
-		}
-		return tx, err
-	})
-	return err
+	return &TxRequest{
+		Description: fmt.Sprintf("RegisterForOperatorSets for %s", operatorAddress.Hex()),
+		Opts:        opts,
+		Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			tx, err := allocationManager.RegisterForOperatorSets(opts, operatorAddress, params)
+			if err == nil && tx != nil {
+				cc.logger.Debug(
+					"Transaction hash for RegisterForOperatorSets: %s\n"+
+						"  operatorAddress: %s\n"+
+						"  avsAddress: %s\n"+
+						"  operatorSetIDs: %v\n"+
+						"  payload: %v\n",
+					tx.Hash().Hex(),
+					operatorAddress.Hex(),
+					avsAddress.Hex(),
+					operatorSetIDs,
+					"0x"+hex.EncodeToString(payload),
+				)
+			}
+			return tx, err
+		},
+	}, nil
 }
 
 func (cc *ContractCaller) DepositIntoStrategy(ctx context.Context, strategyAddress common.Address, amount *big.Int) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 
 	// Get or register the strategy contract
 	strategy, err := cc.registry.GetStrategy(strategyAddress)
@@ -348,11 +434,7 @@ func (cc *ContractCaller) DepositIntoStrategy(ctx context.Context, strategyAddre
 
 	// approve the strategy manager to spend the underlying tokens
 	cc.logger.Info("Approving strategy manager %s to spend %s of token %s", cc.strategyManagerAddr.Hex(), amount.String(), underlyingToken.Hex())
-	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("Approve strategy manager: token %s, amount %s", underlyingToken.Hex(), amount.String()), func() (*types.Transaction, error) {
-		opts, err := cc.buildTxOpts()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build transaction options for approval: %w", err)
-		}
+	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("Approve strategy manager: token %s, amount %s", underlyingToken.Hex(), amount.String()), func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		return erc20Contract.Transact(opts, "approve", cc.strategyManagerAddr, amount)
 	})
 	if err != nil {
@@ -364,7 +446,7 @@ func (cc *ContractCaller) DepositIntoStrategy(ctx context.Context, strategyAddre
 		return fmt.Errorf("failed to get StrategyManager: %w", err)
 	}
 
-	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("DepositIntoStrategy : strategy %s, amount %s", strategyAddress.Hex(), amount.String()), func() (*types.Transaction, error) {
+	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("DepositIntoStrategy : strategy %s, amount %s", strategyAddress.Hex(), amount.String()), func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := strategyManager.DepositIntoStrategy(opts, strategyAddress, underlyingToken, amount)
 		if err == nil && tx != nil {
 			cc.logger.Debug(
@@ -384,14 +466,36 @@ func (cc *ContractCaller) DepositIntoStrategy(ctx context.Context, strategyAddre
 }
 
 func (cc *ContractCaller) DelegateToOperator(ctx context.Context, operatorAddress common.Address, signature DelegationManager.ISignatureUtilsMixinTypesSignatureWithExpiry, approverSalt [32]byte) error {
+	req, err := cc.DelegateToOperatorTx(ctx, operatorAddress, signature, approverSalt)
+	if err != nil || req == nil {
+		return err
+	}
+	return cc.sendAndWait(ctx, *req)
+}
+
+// DelegateToOperatorTx returns the DelegateToOperator transaction for a TxManager batch, nil when the staker is already delegated to the operator
+func (cc *ContractCaller) DelegateToOperatorTx(ctx context.Context, operatorAddress common.Address, signature DelegationManager.ISignatureUtilsMixinTypesSignatureWithExpiry, approverSalt [32]byte) (*TxRequest, error) {
 	opts, err := cc.buildTxOpts()
 	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
 	}
 
 	delegationManager, err := cc.registry.GetDelegationManager(cc.delegationManagerAddr)
 	if err != nil {
-		return fmt.Errorf("failed to get DelegationManager: %w", err)
+		return nil, fmt.Errorf("failed to get DelegationManager: %w", err)
+	}
+
+	staker := crypto.PubkeyToAddress(cc.privateKey.PublicKey)
+	delegatedTo, err := delegationManager.DelegatedTo(&bind.CallOpts{Context: ctx}, staker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check delegation of %s: %w", staker.Hex(), err)
+	}
+	if delegatedTo == operatorAddress {
+		cc.logger.Info("Staker %s already delegated to operator %s, skipping", staker.Hex(), operatorAddress.Hex())
+		return nil, nil
+	}
+	if delegatedTo != (common.Address{}) {
+		return nil, fmt.Errorf("staker %s is already delegated to operator %s, undelegate it before delegating to %s", staker.Hex(), delegatedTo.Hex(), operatorAddress.Hex())
 	}
 
 	cc.logger.Info("DelegateToOperator parameters - Operator: %s, Signature: %s, Expiry: %s, ApproverSalt: %s",
@@ -400,23 +504,26 @@ func (cc *ContractCaller) DelegateToOperator(ctx context.Context, operatorAddres
 		signature.Expiry.String(),
 		hex.EncodeToString(approverSalt[:]))
 
-	err = cc.SendAndWaitForTransaction(ctx, fmt.Sprintf("DelegateToOperator: operator %s", operatorAddress.Hex()), func() (*types.Transaction, error) {
-		tx, err := delegationManager.DelegateTo(opts, operatorAddress, signature, approverSalt)
-		if err == nil && tx != nil {
-			cc.logger.Debug(
-				"Transaction hash for DelegateToOperator: %s\n"+
-					"operatorAddress: %s\n"+
-					"signature: %s\n"+
-					"approverSalt: %s",
-				tx.Hash().Hex(),
-				operatorAddress,
-				signature,
-				approverSalt,
-			)
-		}
-		return tx, err
-	})
-	return err
+	return &TxRequest{
+		Description: fmt.Sprintf("DelegateToOperator: operator %s", operatorAddress.Hex()),
+		Opts:        opts,
+		Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			tx, err := delegationManager.DelegateTo(opts, operatorAddress, signature, approverSalt)
+			if err == nil && tx != nil {
+				cc.logger.Debug(
+					"Transaction hash for DelegateToOperator: %s\n"+
+						"operatorAddress: %s\n"+
+						"signature: %s\n"+
+						"approverSalt: %s",
+					tx.Hash().Hex(),
+					operatorAddress,
+					signature,
+					approverSalt,
+				)
+			}
+			return tx, err
+		},
+	}, nil
 }
 
 func (cc *ContractCaller) CreateApprovalSignature(ctx context.Context, stakerAddress common.Address, operatorAddress common.Address, approverAddress common.Address, approverPrivateKey string, approverSalt [32]byte, expiry *big.Int) (DelegationManager.ISignatureUtilsMixinTypesSignatureWithExpiry, error) {
@@ -462,40 +569,75 @@ func (cc *ContractCaller) CreateApprovalSignature(ctx context.Context, stakerAdd
 }
 
 func (cc *ContractCaller) ModifyAllocations(ctx context.Context, operatorAddress common.Address, operatorPrivateKey string, strategies []common.Address, newMagnitudes []uint64, avsAddress common.Address, opSetId uint32, logger iface.Logger) error {
+	req, err := cc.ModifyAllocationsTx(ctx, operatorAddress, operatorPrivateKey, strategies, newMagnitudes, avsAddress, opSetId, logger)
+	if err != nil || req == nil {
+		return err
+	}
+	return cc.sendAndWait(ctx, *req)
+}
+
+// ModifyAllocationsTx returns the ModifyAllocations transaction for a TxManager batch, nil when every allocation already is, or is pending towards, its target magnitude
+func (cc *ContractCaller) ModifyAllocationsTx(ctx context.Context, operatorAddress common.Address, operatorPrivateKey string, strategies []common.Address, newMagnitudes []uint64, avsAddress common.Address, opSetId uint32, logger iface.Logger) (*TxRequest, error) {
 	opts, err := cc.buildTxOpts()
 	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	allocationManager, err := cc.registry.GetAllocationManager(cc.allocationManagerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AllocationManager: %w", err)
 	}
 
+	// Only modify the allocations that do not already equal, or are not pending towards, the target magnitude
 	operatorSet := allocationmanager.OperatorSet{Avs: avsAddress, Id: opSetId}
+	var pendingStrategies []common.Address
+	var pendingMagnitudes []uint64
+	for i, strategy := range strategies {
+		current, err := allocationManager.GetAllocation(&bind.CallOpts{Context: ctx}, operatorAddress, operatorSet, strategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allocation of %s to operator set %d: %w", operatorAddress.Hex(), opSetId, err)
+		}
+		target := new(big.Int).SetUint64(current.CurrentMagnitude)
+		if current.PendingDiff != nil {
+			target.Add(target, current.PendingDiff)
+		}
+		if target.Cmp(new(big.Int).SetUint64(newMagnitudes[i])) == 0 {
+			cc.logger.Info("Allocation of %s to operator set %d for strategy %s already %d, skipping", operatorAddress.Hex(), opSetId, strategy.Hex(), newMagnitudes[i])
+			continue
+		}
+		pendingStrategies = append(pendingStrategies, strategy)
+		pendingMagnitudes = append(pendingMagnitudes, newMagnitudes[i])
+	}
+	if len(pendingStrategies) == 0 {
+		return nil, nil
+	}
+
 	allocations := []allocationmanager.IAllocationManagerTypesAllocateParams{
 		{
 			OperatorSet:   operatorSet,
-			Strategies:    strategies,
-			NewMagnitudes: newMagnitudes,
+			Strategies:    pendingStrategies,
+			NewMagnitudes: pendingMagnitudes,
 		},
 	}
 
-	allocationManager, err := cc.registry.GetAllocationManager(cc.allocationManagerAddr)
-	if err != nil {
-		return fmt.Errorf("failed to get AllocationManager: %w", err)
-	}
-
-	err = cc.SendAndWaitForTransaction(ctx, "ModifyAllocations", func() (*types.Transaction, error) {
-		tx, err := allocationManager.ModifyAllocations(opts, operatorAddress, allocations)
-		if err == nil && tx != nil {
-			cc.logger.Debug(
-				"Transaction hash for ModifyAllocations: %s\n"+
-					"operatorAddress: %s\n"+
-					"allocations: %s",
-				tx.Hash().Hex(),
-				operatorAddress,
-				allocations,
-			)
-		}
-		return tx, err
-	})
-	return err
+	return &TxRequest{
+		Description: "ModifyAllocations",
+		Opts:        opts,
+		Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			tx, err := allocationManager.ModifyAllocations(opts, operatorAddress, allocations)
+			if err == nil && tx != nil {
+				cc.logger.Debug(
+					"Transaction hash for ModifyAllocations: %s\n"+
+						"operatorAddress: %s\n"+
+						"allocations: %s",
+					tx.Hash().Hex(),
+					operatorAddress,
+					allocations,
+				)
+			}
+			return tx, err
+		},
+	}, nil
 }
 
 func IsValidABI(v interface{}) error {
@@ -557,10 +699,6 @@ func (cc *ContractCaller) RegisterTokensFromStrategies(cfg *OperatorSpec) error
 }
 
 func (cc *ContractCaller) ConfigureOpSetCurveType(ctx context.Context, avsAddress common.Address, opSetId uint32, curveType uint8) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 
 	keyRegistrar, err := cc.registry.GetKeyRegistrar(cc.keyRegistrarAddr)
 	if err != nil {
@@ -582,7 +720,7 @@ func (cc *ContractCaller) ConfigureOpSetCurveType(ctx context.Context, avsAddres
 	}
 
 	operatorSet := keyregistrar.OperatorSet{Avs: avsAddress, Id: opSetId}
-	err = cc.SendAndWaitForTransaction(ctx, "ConfigureOpSetCurveType", func() (*types.Transaction, error) {
+	err = cc.SendAndWaitForTransaction(ctx, "ConfigureOpSetCurveType", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := keyRegistrar.ConfigureOperatorSet(opts, operatorSet, curveType)
 		return tx, err
 	})
@@ -590,10 +728,6 @@ func (cc *ContractCaller) ConfigureOpSetCurveType(ctx context.Context, avsAddres
 }
 
 func (cc *ContractCaller) CreateGenerationReservation(ctx context.Context, opSetId uint32, operatorTableCalculator common.Address, avsAddress common.Address) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 
 	crossChainRegistry, err := cc.registry.GetCrossChainRegistry(cc.crossChainRegistryAddr)
 	if err != nil {
@@ -621,7 +755,7 @@ func (cc *ContractCaller) CreateGenerationReservation(ctx context.Context, opSet
 		MaxStalenessPeriod: 66666666,
 	}
 
-	err = cc.SendAndWaitForTransaction(ctx, "CreateGenerationReservation", func() (*types.Transaction, error) {
+	err = cc.SendAndWaitForTransaction(ctx, "CreateGenerationReservation", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := crossChainRegistry.CreateGenerationReservation(opts, operatorSet, operatorTableCalculator, operatorSetConfig)
 		return tx, err
 	})
@@ -653,8 +787,8 @@ func (cc *ContractCaller) WhitelistChainIdInCrossRegistry(ctx context.Context, o
 	if balance.Cmp(minBalance) < 0 {
 		cc.logger.Info("Funding cross chain registry owner with 1 ETH")
 
-		// Use anvil_setBalance RPC method
-		err = rpcClient.Call(nil, "anvil_setBalance", ownerCrossChainRegistry.Hex(), "0x8AC7230489E80000") // 10 ETH in hex
+		// hardhat_setBalance is served by both anvil and hardhat nodes
+		err = rpcClient.Call(nil, "hardhat_setBalance", ownerCrossChainRegistry.Hex(), "0x8AC7230489E80000") // 10 ETH in hex
 		if err != nil {
 			return fmt.Errorf("failed to set owner balance: %w", err)
 		}
@@ -737,10 +871,6 @@ func (cc *ContractCaller) WhitelistChainIdInCrossRegistry(ctx context.Context, o
 }
 
 func (cc *ContractCaller) RegisterKeyInKeyRegistrar(ctx context.Context, operatorAddress common.Address, avsAddress common.Address, opSetId uint32, keyData []byte, signature bn254.Signature) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 
 	keyRegistrar, err := cc.registry.GetKeyRegistrar(cc.keyRegistrarAddr)
 	if err != nil {
@@ -766,7 +896,7 @@ func (cc *ContractCaller) RegisterKeyInKeyRegistrar(ctx context.Context, operato
 		return nil
 	}
 
-	err = cc.SendAndWaitForTransaction(ctx, "RegisterKeyInKeyRegistrar", func() (*types.Transaction, error) {
+	err = cc.SendAndWaitForTransaction(ctx, "RegisterKeyInKeyRegistrar", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := keyRegistrar.RegisterKey(opts, operatorAddress, operatorSet, keyData, g1Bytes)
 		return tx, err
 	})
@@ -779,10 +909,6 @@ func (cc *ContractCaller) GetRegistry() *contracts.ContractRegistry {
 }
 
 func (cc *ContractCaller) PublishRelease(ctx context.Context, avsAddress common.Address, artifacts []releasemanager.IReleaseManagerTypesArtifact, operatorSetId uint32, upgradeByTime uint32) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 	releaseManager, err := cc.registry.GetReleaseManager(cc.releaseManagerAddr)
 	if err != nil {
 		return fmt.Errorf("failed to get ReleaseManager: %w", err)
@@ -792,7 +918,7 @@ func (cc *ContractCaller) PublishRelease(ctx context.Context, avsAddress common.
 		Artifacts:     artifacts,
 		UpgradeByTime: upgradeByTime,
 	}
-	return cc.SendAndWaitForTransaction(ctx, "PublishRelease", func() (*types.Transaction, error) {
+	return cc.SendAndWaitForTransaction(ctx, "PublishRelease", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := releaseManager.PublishRelease(opts, operatorSet, release)
 		if err == nil && tx != nil {
 			cc.logger.Info("Transaction hash for PublishRelease: %s", tx.Hash().Hex())
@@ -820,16 +946,12 @@ func (cc *ContractCaller) SetReleaseMetadata(
 	avsAddress common.Address,
 	operatorSetId uint32,
 ) error {
-	opts, err := cc.buildTxOpts()
-	if err != nil {
-		return fmt.Errorf("failed to build transaction options: %w", err)
-	}
 	releaseManager, err := cc.registry.GetReleaseManager(cc.releaseManagerAddr)
 	if err != nil {
 		return fmt.Errorf("failed to set ReleaseManager metadata uri: %w", err)
 	}
 	operatorSet := releasemanager.OperatorSet{Avs: avsAddress, Id: operatorSetId}
-	return cc.SendAndWaitForTransaction(ctx, "PublishMetadataURI", func() (*types.Transaction, error) {
+	return cc.SendAndWaitForTransaction(ctx, "PublishMetadataURI", func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		tx, err := releaseManager.PublishMetadataURI(opts, operatorSet, metadataUri)
 		if err == nil && tx != nil {
 			cc.logger.Info(
@@ -0,0 +1,184 @@
+package common_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/devnet"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/testchain"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptAllRegistrar returns 32 bytes holding 1 to every call, so it supports any AVS and accepts every registration
+var acceptAllRegistrar = ethcommon.HexToAddress("0x00000000000000000000000000000000000a5a5a")
+
+// eigenLayerChain is an anvil chain with the EigenLayer core contracts deployed by devnet.BootstrapEigenLayer
+type eigenLayerChain struct {
+	*testchain.Chain
+	chainID    *big.Int
+	deployment *devnet.CoreDeployment
+}
+
+// newEigenLayerChain bootstraps EigenLayer onto an anvil L1 and L2, funding keys on the L1
+func newEigenLayerChain(t *testing.T, keys ...*ecdsa.PrivateKey) *eigenLayerChain {
+	deployerKey, err := crypto.HexToECDSA(devnet.ANVIL_1_KEY[2:])
+	require.NoError(t, err)
+
+	l1, l2 := testchain.Start(t), testchain.Start(t)
+	funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+	for _, key := range keys {
+		l1.SetBalance(t, crypto.PubkeyToAddress(key.PublicKey), funds)
+	}
+	l1.SetCode(t, acceptAllRegistrar, ethcommon.FromHex("600160005260206000f3"))
+
+	chain := func(name string, c *testchain.Chain) *devnet.BootstrapChain {
+		bootstrapChain, closeChain, err := devnet.DialBootstrapChain(context.Background(), name, devnet.AnvilBackend{}, c.URL)
+		require.NoError(t, err)
+		t.Cleanup(closeChain)
+		return bootstrapChain
+	}
+	deployment, err := devnet.BootstrapEigenLayer(context.Background(), chain("L1", l1), []*devnet.BootstrapChain{chain("L2", l2)}, devnet.BootstrapConfig{
+		DeployerKey:             deployerKey,
+		CrossChainRegistryOwner: crypto.PubkeyToAddress(deployerKey.PublicKey),
+	}, logger.NewNoopLogger())
+	require.NoError(t, err)
+
+	chainID, err := l1.Client.ChainID(context.Background())
+	require.NoError(t, err)
+	return &eigenLayerChain{Chain: l1, chainID: chainID, deployment: deployment}
+}
+
+// caller returns a ContractCaller sending from key
+func (c *eigenLayerChain) caller(t *testing.T, key *ecdsa.PrivateKey) *common.ContractCaller {
+	cc, err := common.NewContractCaller(
+		hex.EncodeToString(crypto.FromECDSA(key)),
+		c.chainID,
+		c.Client,
+		c.deployment.AllocationManager,
+		c.deployment.DelegationManager,
+		c.deployment.StrategyManager,
+		c.deployment.KeyRegistrar,
+		c.deployment.CrossChainRegistry,
+		c.deployment.ReleaseManager,
+		ethcommon.Address{},
+		logger.NewNoopLogger(),
+	)
+	require.NoError(t, err)
+	return cc
+}
+
+// assertSendsNothing runs step and checks it did not send a transaction from key
+func (c *eigenLayerChain) assertSendsNothing(t *testing.T, key *ecdsa.PrivateKey, step func() error) {
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	before, err := c.Client.PendingNonceAt(context.Background(), from)
+	require.NoError(t, err)
+	require.NoError(t, step())
+	after, err := c.Client.PendingNonceAt(context.Background(), from)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "expected no transaction from %s", from.Hex())
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func TestContractCallerReconcilesAVSSetup(t *testing.T) {
+	avsKey, operatorKey := newKey(t), newKey(t)
+	chain := newEigenLayerChain(t, avsKey, operatorKey)
+	ctx := context.Background()
+	avs := crypto.PubkeyToAddress(avsKey.PublicKey)
+	operator := crypto.PubkeyToAddress(operatorKey.PublicKey)
+	strategyA := ethcommon.HexToAddress("0x000000000000000000000000000000000000a001")
+	strategyB := ethcommon.HexToAddress("0x000000000000000000000000000000000000b001")
+
+	avsCaller := chain.caller(t, avsKey)
+	operatorCaller := chain.caller(t, operatorKey)
+	allocationManager, err := allocationmanager.NewAllocationManager(chain.deployment.AllocationManager, chain.Client)
+	require.NoError(t, err)
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	require.NoError(t, avsCaller.UpdateAVSMetadata(ctx, avs, "https://example.com/avs.json"))
+
+	// The registrar is only set once
+	require.NoError(t, avsCaller.SetAVSRegistrar(ctx, avs, acceptAllRegistrar))
+	chain.assertSendsNothing(t, avsKey, func() error { return avsCaller.SetAVSRegistrar(ctx, avs, acceptAllRegistrar) })
+
+	// An existing operator set only gets the strategies it is missing
+	createSet := func(strategies ...ethcommon.Address) error {
+		return avsCaller.CreateOperatorSets(ctx, avs, []allocationmanager.IAllocationManagerTypesCreateSetParams{{OperatorSetId: 0, Strategies: strategies}})
+	}
+	require.NoError(t, createSet(strategyA))
+	chain.assertSendsNothing(t, avsKey, func() error { return createSet(strategyA) })
+	require.NoError(t, createSet(strategyA, strategyB))
+	strategies, err := allocationManager.GetStrategiesInOperatorSet(callOpts, allocationmanager.OperatorSet{Avs: avs, Id: 0})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []ethcommon.Address{strategyA, strategyB}, strategies)
+	chain.assertSendsNothing(t, avsKey, func() error { return createSet(strategyA, strategyB) })
+
+	// Operators register with EigenLayer and the operator set once
+	require.NoError(t, operatorCaller.RegisterAsOperator(ctx, operator, 0, "test"))
+	chain.assertSendsNothing(t, operatorKey, func() error { return operatorCaller.RegisterAsOperator(ctx, operator, 0, "test") })
+	require.NoError(t, operatorCaller.RegisterForOperatorSets(ctx, operator, avs, []uint32{0}, nil))
+	chain.assertSendsNothing(t, operatorKey, func() error {
+		return operatorCaller.RegisterForOperatorSets(ctx, operator, avs, []uint32{0}, nil)
+	})
+
+	// Allocations already at their target magnitude are not modified again
+	require.Eventually(t, func() bool {
+		require.NoError(t, chain.Mine(ctx))
+		isSet, _, err := allocationManager.GetAllocationDelay(callOpts, operator)
+		return err == nil && isSet
+	}, 10*time.Second, 100*time.Millisecond)
+	allocate := func(magnitudes ...uint64) error {
+		return operatorCaller.ModifyAllocations(ctx, operator, "", []ethcommon.Address{strategyA, strategyB}, magnitudes, avs, 0, logger.NewNoopLogger())
+	}
+	require.NoError(t, allocate(1e17, 2e17))
+	chain.assertSendsNothing(t, operatorKey, func() error { return allocate(1e17, 2e17) })
+	require.NoError(t, allocate(1e17, 3e17))
+	allocation, err := allocationManager.GetAllocation(callOpts, operator, allocationmanager.OperatorSet{Avs: avs, Id: 0}, strategyB)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3e17), allocation.CurrentMagnitude)
+}
+
+func TestContractCallerDelegateToOperator(t *testing.T) {
+	stakerKey, operatorKey, otherOperatorKey := newKey(t), newKey(t), newKey(t)
+	chain := newEigenLayerChain(t, stakerKey, operatorKey, otherOperatorKey)
+	ctx := context.Background()
+	operator := crypto.PubkeyToAddress(operatorKey.PublicKey)
+	otherOperator := crypto.PubkeyToAddress(otherOperatorKey.PublicKey)
+
+	require.NoError(t, chain.caller(t, operatorKey).RegisterAsOperator(ctx, operator, 0, "test"))
+	require.NoError(t, chain.caller(t, otherOperatorKey).RegisterAsOperator(ctx, otherOperator, 0, "test"))
+
+	// RegisterAsOperator makes each operator its own delegation approver
+	stakerCaller := chain.caller(t, stakerKey)
+	staker := crypto.PubkeyToAddress(stakerKey.PublicKey)
+	delegate := func(operatorKey *ecdsa.PrivateKey, salt byte) error {
+		operator := crypto.PubkeyToAddress(operatorKey.PublicKey)
+		expiry := big.NewInt(time.Now().Add(time.Hour).Unix())
+		signature, err := stakerCaller.CreateApprovalSignature(ctx, staker, operator, operator, hex.EncodeToString(crypto.FromECDSA(operatorKey)), [32]byte{salt}, expiry)
+		require.NoError(t, err)
+		return stakerCaller.DelegateToOperator(ctx, operator, signature, [32]byte{salt})
+	}
+	require.NoError(t, delegate(operatorKey, 1))
+	chain.assertSendsNothing(t, stakerKey, func() error { return delegate(operatorKey, 2) })
+
+	// Delegating to another operator can't converge without undelegating first
+	err := delegate(otherOperatorKey, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already delegated to operator "+operator.Hex())
+}
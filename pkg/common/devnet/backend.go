@@ -0,0 +1,247 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Names of the chain backends accepted in a chain's `backend` field
+const (
+	AnvilBackendName   = "anvil"
+	HardhatBackendName = "hardhat"
+)
+
+// ErrUnsupportedByBackend is returned by ChainBackend methods the node cannot perform
+var ErrUnsupportedByBackend = errors.New("not supported by this chain backend")
+
+// ChainStartOptions are the settings a devnet chain container is started with
+type ChainStartOptions struct {
+	ChainID   int
+	BlockTime int
+	// ForkURL is the node forked, empty starts an empty chain
+	ForkURL string
+	// ForkBlock is the block forked, 0 forks the latest block
+	ForkBlock int
+	// Args are extra backend specific arguments
+	Args string
+}
+
+// ChainBackend starts a devnet chain node and drives the dev RPCs it exposes beyond the standard eth namespace
+type ChainBackend interface {
+	Name() string
+	// DefaultImage is the docker image the chain runs when the context sets none
+	DefaultImage() string
+	// ContainerCommand returns the entrypoint and command of a chain container serving its RPC on port 8545
+	ContainerCommand(opts ChainStartOptions) (entrypoint []string, command []string)
+	Impersonate(ctx context.Context, client *rpc.Client, account common.Address) error
+	StopImpersonating(ctx context.Context, client *rpc.Client, account common.Address) error
+	SetBalance(ctx context.Context, client *rpc.Client, account common.Address, wei *big.Int) error
+	SetStorageAt(ctx context.Context, client *rpc.Client, account common.Address, key, value common.Hash) error
+	SetCode(ctx context.Context, client *rpc.Client, account common.Address, code []byte) error
+	// Mine seals blocks immediately
+	Mine(ctx context.Context, client *rpc.Client, blocks uint64) error
+	// SetNextBlockTimestamp stamps the next mined block with timestamp
+	SetNextBlockTimestamp(ctx context.Context, client *rpc.Client, timestamp uint64) error
+	// DumpState returns a snapshot of the chain state which LoadState merges back in
+	DumpState(ctx context.Context, client *rpc.Client) (string, error)
+	LoadState(ctx context.Context, client *rpc.Client, state string) error
+}
+
+// ChainBackends are the chain backends known to devkit keyed by name
+var ChainBackends = map[string]ChainBackend{
+	AnvilBackendName:   AnvilBackend{},
+	HardhatBackendName: HardhatBackend{},
+}
+
+// GetChainBackend returns the named backend, anvil when name is empty
+func GetChainBackend(name string) (ChainBackend, error) {
+	if name == "" {
+		name = AnvilBackendName
+	}
+	backend, ok := ChainBackends[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(ChainBackends))
+		for known := range ChainBackends {
+			names = append(names, known)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown chain backend %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return backend, nil
+}
+
+// ChainBackendFor returns the backend a context chain runs on
+func ChainBackendFor(chainName string, chain devkitcommon.ChainConfig) (ChainBackend, error) {
+	backend, err := GetChainBackend(chain.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("chain %s: %w", chainName, err)
+	}
+	return backend, nil
+}
+
+// AnvilBackend runs chains on Foundry's anvil
+type AnvilBackend struct{}
+
+func (AnvilBackend) Name() string { return AnvilBackendName }
+
+func (AnvilBackend) DefaultImage() string { return FOUNDRY_IMAGE }
+
+func (AnvilBackend) ContainerCommand(opts ChainStartOptions) ([]string, []string) {
+	args := []string{"--host", "0.0.0.0"}
+	if opts.ForkURL != "" {
+		args = append(args, "--fork-url", opts.ForkURL)
+		// Without a fork block anvil forks the latest block
+		if opts.ForkBlock != 0 {
+			args = append(args, "--fork-block-number", strconv.Itoa(opts.ForkBlock))
+		}
+	}
+	args = append(args, strings.Fields(opts.Args)...)
+	args = append(args, "--chain-id", strconv.Itoa(opts.ChainID), "--block-time", strconv.Itoa(opts.BlockTime))
+	return []string{"anvil"}, args
+}
+
+func (AnvilBackend) Impersonate(ctx context.Context, client *rpc.Client, account common.Address) error {
+	return callDevRPC(ctx, client, "anvil_impersonateAccount", account)
+}
+
+func (AnvilBackend) StopImpersonating(ctx context.Context, client *rpc.Client, account common.Address) error {
+	return callDevRPC(ctx, client, "anvil_stopImpersonatingAccount", account)
+}
+
+func (AnvilBackend) SetBalance(ctx context.Context, client *rpc.Client, account common.Address, wei *big.Int) error {
+	return callDevRPC(ctx, client, "anvil_setBalance", account, (*hexutil.Big)(wei))
+}
+
+func (AnvilBackend) SetStorageAt(ctx context.Context, client *rpc.Client, account common.Address, key, value common.Hash) error {
+	return callDevRPC(ctx, client, "anvil_setStorageAt", account, key, value)
+}
+
+func (AnvilBackend) SetCode(ctx context.Context, client *rpc.Client, account common.Address, code []byte) error {
+	return callDevRPC(ctx, client, "anvil_setCode", account, hexutil.Bytes(code))
+}
+
+func (AnvilBackend) Mine(ctx context.Context, client *rpc.Client, blocks uint64) error {
+	return callDevRPC(ctx, client, "anvil_mine", blocks)
+}
+
+func (AnvilBackend) SetNextBlockTimestamp(ctx context.Context, client *rpc.Client, timestamp uint64) error {
+	return callDevRPC(ctx, client, "evm_setNextBlockTimestamp", timestamp)
+}
+
+func (AnvilBackend) DumpState(ctx context.Context, client *rpc.Client) (string, error) {
+	var state string
+	if err := client.CallContext(ctx, &state, "anvil_dumpState"); err != nil {
+		return "", fmt.Errorf("anvil_dumpState failed: %w", err)
+	}
+	if state == "" {
+		return "", fmt.Errorf("anvil_dumpState returned no state")
+	}
+	return state, nil
+}
+
+func (AnvilBackend) LoadState(ctx context.Context, client *rpc.Client, state string) error {
+	var ok bool
+	if err := client.CallContext(ctx, &ok, "anvil_loadState", strings.TrimSpace(state)); err != nil {
+		return fmt.Errorf("anvil_loadState failed: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("anvil_loadState was rejected")
+	}
+	return nil
+}
+
+// HardhatBackend runs chains on a Hardhat Network node, installed into a node image when the container starts
+type HardhatBackend struct{}
+
+func (HardhatBackend) Name() string { return HardhatBackendName }
+
+func (HardhatBackend) DefaultImage() string { return HARDHAT_IMAGE }
+
+func (HardhatBackend) ContainerCommand(opts ChainStartOptions) ([]string, []string) {
+	// The chain id and block time can only be set through hardhat.config.js
+	config := fmt.Sprintf(
+		"module.exports = { networks: { hardhat: { chainId: %d, initialBaseFeePerGas: 0, mining: { auto: %t, interval: %d } } } };",
+		opts.ChainID, opts.BlockTime == 0, opts.BlockTime*1000,
+	)
+
+	node := "npx hardhat node --hostname 0.0.0.0 --port 8545"
+	if opts.ForkURL != "" {
+		node += " --fork " + shellQuote(opts.ForkURL)
+		if opts.ForkBlock != 0 {
+			node += fmt.Sprintf(" --fork-block-number %d", opts.ForkBlock)
+		}
+	}
+	if opts.Args != "" {
+		node += " " + opts.Args
+	}
+
+	script := strings.Join([]string{
+		"mkdir -p /hardhat",
+		"cd /hardhat",
+		fmt.Sprintf("[ -d node_modules/hardhat ] || npm install --silent --no-fund --no-audit %s", HARDHAT_PACKAGE),
+		fmt.Sprintf("echo '%s' > hardhat.config.js", config),
+		"exec " + node,
+	}, " && ")
+	return []string{"sh", "-c"}, []string{script}
+}
+
+// shellQuote quotes s as a single sh word, so fork URLs holding quotes or shell metacharacters reach hardhat verbatim
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func (HardhatBackend) Impersonate(ctx context.Context, client *rpc.Client, account common.Address) error {
+	return callDevRPC(ctx, client, "hardhat_impersonateAccount", account)
+}
+
+func (HardhatBackend) StopImpersonating(ctx context.Context, client *rpc.Client, account common.Address) error {
+	return callDevRPC(ctx, client, "hardhat_stopImpersonatingAccount", account)
+}
+
+func (HardhatBackend) SetBalance(ctx context.Context, client *rpc.Client, account common.Address, wei *big.Int) error {
+	return callDevRPC(ctx, client, "hardhat_setBalance", account, (*hexutil.Big)(wei))
+}
+
+func (HardhatBackend) SetStorageAt(ctx context.Context, client *rpc.Client, account common.Address, key, value common.Hash) error {
+	// Hardhat takes the slot as a quantity, rejecting leading zeros
+	return callDevRPC(ctx, client, "hardhat_setStorageAt", account, (*hexutil.Big)(key.Big()), value)
+}
+
+func (HardhatBackend) SetCode(ctx context.Context, client *rpc.Client, account common.Address, code []byte) error {
+	return callDevRPC(ctx, client, "hardhat_setCode", account, hexutil.Bytes(code))
+}
+
+func (HardhatBackend) Mine(ctx context.Context, client *rpc.Client, blocks uint64) error {
+	return callDevRPC(ctx, client, "hardhat_mine", hexutil.Uint64(blocks))
+}
+
+func (HardhatBackend) SetNextBlockTimestamp(ctx context.Context, client *rpc.Client, timestamp uint64) error {
+	return callDevRPC(ctx, client, "evm_setNextBlockTimestamp", timestamp)
+}
+
+func (HardhatBackend) DumpState(context.Context, *rpc.Client) (string, error) {
+	return "", fmt.Errorf("dumping chain state: %w", ErrUnsupportedByBackend)
+}
+
+func (HardhatBackend) LoadState(context.Context, *rpc.Client, string) error {
+	return fmt.Errorf("loading chain state: %w", ErrUnsupportedByBackend)
+}
+
+// callDevRPC calls a dev RPC method whose result is not needed
+func callDevRPC(ctx context.Context, client *rpc.Client, method string, args ...interface{}) error {
+	if err := client.CallContext(ctx, nil, method, args...); err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+	return nil
+}
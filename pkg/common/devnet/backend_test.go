@@ -0,0 +1,115 @@
+package devnet
+
+import (
+	"context"
+	"math/big"
+	"os/exec"
+	"strings"
+	"testing"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHardhat implements the hardhat_* dev methods over JSON-RPC
+type fakeHardhat struct {
+	mined   uint64
+	storage map[common.Address]map[string]common.Hash
+	balance map[common.Address]*big.Int
+}
+
+func (f *fakeHardhat) Mine(blocks hexutil.Uint64) bool {
+	f.mined += uint64(blocks)
+	return true
+}
+
+func (f *fakeHardhat) SetStorageAt(account common.Address, slot *hexutil.Big, value common.Hash) bool {
+	if f.storage[account] == nil {
+		f.storage[account] = map[string]common.Hash{}
+	}
+	f.storage[account][slot.String()] = value
+	return true
+}
+
+func (f *fakeHardhat) SetBalance(account common.Address, wei *hexutil.Big) bool {
+	f.balance[account] = wei.ToInt()
+	return true
+}
+
+func TestGetChainBackend(t *testing.T) {
+	backend, err := GetChainBackend("")
+	require.NoError(t, err)
+	assert.Equal(t, AnvilBackendName, backend.Name())
+
+	backend, err = GetChainBackend("Hardhat")
+	require.NoError(t, err)
+	assert.Equal(t, HardhatBackendName, backend.Name())
+
+	_, err = ChainBackendFor("l2", devkitcommon.ChainConfig{Backend: "ganache"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "chain l2")
+	assert.Contains(t, err.Error(), "anvil, hardhat")
+}
+
+func TestAnvilContainerCommand(t *testing.T) {
+	entrypoint, command := AnvilBackend{}.ContainerCommand(ChainStartOptions{
+		ChainID:   31337,
+		BlockTime: 2,
+		ForkURL:   "https://rpc.example",
+		Args:      "--steps-tracing",
+	})
+	assert.Equal(t, []string{"anvil"}, entrypoint)
+	assert.Equal(t, []string{
+		"--host", "0.0.0.0", "--fork-url", "https://rpc.example", "--steps-tracing",
+		"--chain-id", "31337", "--block-time", "2",
+	}, command)
+}
+
+func TestHardhatContainerCommand(t *testing.T) {
+	entrypoint, command := HardhatBackend{}.ContainerCommand(ChainStartOptions{
+		ChainID:   31338,
+		BlockTime: 3,
+		ForkURL:   "https://rpc.example",
+		ForkBlock: 100,
+	})
+	assert.Equal(t, []string{"sh", "-c"}, entrypoint)
+	require.Len(t, command, 1)
+	assert.Contains(t, command[0], HARDHAT_PACKAGE)
+	assert.Contains(t, command[0], "chainId: 31338")
+	assert.Contains(t, command[0], "mining: { auto: false, interval: 3000 }")
+	assert.True(t, strings.HasSuffix(command[0], "exec npx hardhat node --hostname 0.0.0.0 --port 8545 --fork 'https://rpc.example' --fork-block-number 100"))
+}
+
+func TestShellQuote(t *testing.T) {
+	for _, value := range []string{"https://rpc.example/v2/key", "https://rpc.example/'; rm -rf / #", "https://rpc.example/?a=$HOME&b=`id`"} {
+		out, err := exec.Command("sh", "-c", "printf %s "+shellQuote(value)).Output()
+		require.NoError(t, err)
+		assert.Equal(t, value, string(out))
+	}
+}
+
+func TestHardhatBackendRPC(t *testing.T) {
+	hardhat := &fakeHardhat{storage: map[common.Address]map[string]common.Hash{}, balance: map[common.Address]*big.Int{}}
+	client := dialFakeChain(t, map[string]any{"hardhat": hardhat})
+
+	ctx := context.Background()
+	backend := HardhatBackend{}
+	account := common.HexToAddress("0x1234")
+
+	require.NoError(t, backend.Mine(ctx, client, 5))
+	assert.Equal(t, uint64(5), hardhat.mined)
+
+	value := common.BigToHash(big.NewInt(42))
+	require.NoError(t, backend.SetStorageAt(ctx, client, account, common.BigToHash(big.NewInt(9)), value))
+	assert.Equal(t, value, hardhat.storage[account]["0x9"])
+
+	require.NoError(t, backend.SetBalance(ctx, client, account, big.NewInt(1e18)))
+	assert.Equal(t, big.NewInt(1e18), hardhat.balance[account])
+
+	err := backend.LoadState(ctx, client, "0x00")
+	assert.ErrorIs(t, err, ErrUnsupportedByBackend)
+}
@@ -0,0 +1,96 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxBalanceSlotProbe is the highest storage slot probed when looking for a token's balances mapping
+const maxBalanceSlotProbe = 100
+
+// ErrBalanceSlotNotFound is returned when no probed slot holds the token's balances, e.g. for share based tokens like stETH
+var ErrBalanceSlotNotFound = errors.New("balance slot not found")
+
+// balanceProbeAccount is the account whose balance is written while probing, it never holds real funds
+var balanceProbeAccount = common.BytesToAddress(crypto.Keccak256([]byte("devkit.devnet.balance-probe"))[12:])
+
+// balanceProbeValue is written to candidate slots, chosen to be unlikely to match an existing balance
+var balanceProbeValue = new(big.Int).SetBytes(crypto.Keccak256([]byte("devkit.devnet.balance-probe-value"))[:12])
+
+// BalanceSlot locates an ERC20 token's balances mapping in storage
+type BalanceSlot struct {
+	Slot uint64
+	// Vyper mappings hash the slot before the key
+	Vyper bool
+}
+
+// StorageKey returns the storage key holding account's balance
+func (s BalanceSlot) StorageKey(account common.Address) common.Hash {
+	if s.Vyper {
+		return crypto.Keccak256Hash(
+			common.LeftPadBytes(new(big.Int).SetUint64(s.Slot).Bytes(), 32),
+			common.LeftPadBytes(account.Bytes(), 32),
+		)
+	}
+	return BalanceStorageKey(account, s.Slot)
+}
+
+// DiscoverBalanceSlot finds the token's balances mapping by writing a probe value to each candidate slot
+// and checking whether balanceOf reflects it. Every probed slot is restored before moving on. Share based tokens
+// in DefaultTokenHolders are not probed, and tokens without a mapping are only probed once.
+func (f *Funder) DiscoverBalanceSlot(ctx context.Context, token common.Address) (BalanceSlot, error) {
+	if slot, ok := f.balanceSlots[token]; ok {
+		return slot, nil
+	}
+	if holder, ok := DefaultTokenHolders[token]; ok && holder.ShareBased {
+		return BalanceSlot{}, fmt.Errorf("%w for token %s, %s balances are derived from shares", ErrBalanceSlotNotFound, token.Hex(), holder.TokenName)
+	}
+	if f.missingBalanceSlots[token] {
+		return BalanceSlot{}, fmt.Errorf("%w for token %s in slots 0-%d", ErrBalanceSlotNotFound, token.Hex(), maxBalanceSlotProbe)
+	}
+
+	for slot := uint64(0); slot <= maxBalanceSlotProbe; slot++ {
+		for _, vyper := range []bool{false, true} {
+			candidate := BalanceSlot{Slot: slot, Vyper: vyper}
+			found, err := f.probeBalanceSlot(ctx, token, candidate)
+			if err != nil {
+				return BalanceSlot{}, err
+			}
+			if found {
+				f.balanceSlots[token] = candidate
+				return candidate, nil
+			}
+		}
+	}
+	f.missingBalanceSlots[token] = true
+	return BalanceSlot{}, fmt.Errorf("%w for token %s in slots 0-%d", ErrBalanceSlotNotFound, token.Hex(), maxBalanceSlotProbe)
+}
+
+// probeBalanceSlot writes the probe value at the candidate key, reads balanceOf and restores the original value
+func (f *Funder) probeBalanceSlot(ctx context.Context, token common.Address, candidate BalanceSlot) (found bool, err error) {
+	key := candidate.StorageKey(balanceProbeAccount)
+
+	var original common.Hash
+	if err := f.rpcClient.CallContext(ctx, &original, "eth_getStorageAt", token, key, "latest"); err != nil {
+		return false, fmt.Errorf("failed to read storage of token %s: %w", token.Hex(), err)
+	}
+	if err := f.backend.SetStorageAt(ctx, f.rpcClient, token, key, common.BigToHash(balanceProbeValue)); err != nil {
+		return false, fmt.Errorf("failed to write storage of token %s: %w", token.Hex(), err)
+	}
+	defer func() {
+		if restoreErr := f.backend.SetStorageAt(ctx, f.rpcClient, token, key, original); restoreErr != nil && err == nil {
+			err = fmt.Errorf("failed to restore storage of token %s: %w", token.Hex(), restoreErr)
+		}
+	}()
+
+	balance, err := f.TokenBalance(ctx, token, balanceProbeAccount)
+	if err != nil {
+		return false, err
+	}
+	return balance.Cmp(balanceProbeValue) == 0, nil
+}
@@ -0,0 +1,586 @@
+package devnet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	bn254certificateverifier "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/BN254CertificateVerifier"
+	backingeigen "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/BackingEigen"
+	crosschainregistry "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/CrossChainRegistry"
+	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	ecdsacertificateverifier "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/ECDSACertificateVerifier"
+	eigenpodmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/EigenPodManager"
+	keyregistrar "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/KeyRegistrar"
+	operatortableupdater "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/OperatorTableUpdater"
+	pauserregistry "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/PauserRegistry"
+	permissioncontroller "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/PermissionController"
+	releasemanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/ReleaseManager"
+	strategybase "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyBase"
+	strategymanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyManager"
+	taskmailbox "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/TaskMailbox"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BootstrapBackend is the client interface required to deploy contracts during bootstrap
+type BootstrapBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// BootstrapChain is a chain the EigenLayer core contracts are deployed onto
+type BootstrapChain struct {
+	Name    string
+	Backend BootstrapBackend
+	// Mine seals the pending transactions into a block so bootstrap does not wait on interval mining
+	Mine func(ctx context.Context) error
+	// SetCode replaces the runtime code at an address
+	SetCode func(ctx context.Context, addr common.Address, code []byte) error
+}
+
+// DialBootstrapChain connects to a devnet chain node, returning the chain and a func to close the connection
+func DialBootstrapChain(ctx context.Context, name string, backend ChainBackend, rpcURL string) (*BootstrapChain, func(), error) {
+	rpcClient, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s RPC: %w", name, err)
+	}
+
+	chain := &BootstrapChain{
+		Name:    name,
+		Backend: ethclient.NewClient(rpcClient),
+		Mine: func(ctx context.Context) error {
+			return backend.Mine(ctx, rpcClient, 1)
+		},
+		SetCode: func(ctx context.Context, addr common.Address, code []byte) error {
+			return backend.SetCode(ctx, rpcClient, addr, code)
+		},
+	}
+	return chain, rpcClient.Close, nil
+}
+
+// BootstrapConfig configures BootstrapEigenLayer
+type BootstrapConfig struct {
+	// DeployerKey deploys and owns every contract
+	DeployerKey *ecdsa.PrivateKey
+	// CrossChainRegistryOwner owns the CrossChainRegistry so chain ids can be whitelisted by impersonation
+	CrossChainRegistryOwner common.Address
+	// Strategies are the strategy addresses referenced in context, each is installed as a StrategyBase over a fresh mock token
+	Strategies []common.Address
+	// TokenRecipients are minted STRATEGY_TOKEN_FUNDING_AMOUNT_BY_LARGE_HOLDER_IN_ETH of every mock strategy token
+	TokenRecipients []common.Address
+}
+
+// MultichainDeployment holds the addresses of the contracts deployed to both L1 and L2
+type MultichainDeployment struct {
+	OperatorTableUpdater     common.Address
+	BN254CertificateVerifier common.Address
+	ECDSACertificateVerifier common.Address
+	TaskMailbox              common.Address
+}
+
+// CoreDeployment holds the addresses of the EigenLayer core contracts deployed by BootstrapEigenLayer
+type CoreDeployment struct {
+	AllocationManager    common.Address
+	DelegationManager    common.Address
+	StrategyManager      common.Address
+	KeyRegistrar         common.Address
+	CrossChainRegistry   common.Address
+	ReleaseManager       common.Address
+	PermissionController common.Address
+	L1                   MultichainDeployment
+	L2                   MultichainDeployment
+	// StrategyTokens maps each installed strategy to its mock underlying token
+	StrategyTokens map[common.Address]common.Address
+}
+
+// BootstrapEigenLayer deploys the EigenLayer core contracts from the bundled bindings onto plain anvil chains.
+// The multichain contracts (OperatorTableUpdater, certificate verifiers, TaskMailbox) are deployed to every chain,
+// the core contracts and mock strategies to L1 only.
+func BootstrapEigenLayer(ctx context.Context, l1 *BootstrapChain, l2s []*BootstrapChain, cfg BootstrapConfig, logger iface.Logger) (*CoreDeployment, error) {
+	if len(l2s) == 0 {
+		return nil, fmt.Errorf("at least one l2 chain is required")
+	}
+	l1Deployer, err := newDeployer(ctx, l1, cfg.DeployerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &CoreDeployment{StrategyTokens: map[common.Address]common.Address{}}
+
+	// Deploy the multichain contracts to each chain
+	logger.Info("Deploying multichain contracts to L1...")
+	l1PauserRegistry, err := l1Deployer.deployPauserRegistry()
+	if err != nil {
+		return nil, err
+	}
+	l1Multichain, err := l1Deployer.deployMultichain(l1PauserRegistry)
+	if err != nil {
+		return nil, err
+	}
+	deployment.L1 = *l1Multichain
+
+	for i, l2 := range l2s {
+		logger.Info("Deploying multichain contracts to %s...", l2.Name)
+		l2Deployer, err := newDeployer(ctx, l2, cfg.DeployerKey)
+		if err != nil {
+			return nil, err
+		}
+		l2PauserRegistry, err := l2Deployer.deployPauserRegistry()
+		if err != nil {
+			return nil, err
+		}
+		l2Multichain, err := l2Deployer.deployMultichain(l2PauserRegistry)
+		if err != nil {
+			return nil, err
+		}
+
+		// The context records a single set of L2 addresses, so every L2 must share them
+		if i == 0 {
+			deployment.L2 = *l2Multichain
+		} else if *l2Multichain != deployment.L2 {
+			return nil, fmt.Errorf("multichain contracts on %s were deployed to different addresses than on %s, the deployer account must be unused on every L2", l2.Name, l2s[0].Name)
+		}
+	}
+
+	// Deploy the core contracts to L1
+	logger.Info("Deploying EigenLayer core contracts to L1...")
+	if err := l1Deployer.deployCore(deployment, l1PauserRegistry, cfg.CrossChainRegistryOwner); err != nil {
+		return nil, err
+	}
+
+	// Install mock strategies at the addresses referenced in context
+	if len(cfg.Strategies) > 0 {
+		logger.Info("Installing %d mock strategies on L1...", len(cfg.Strategies))
+		if err := l1Deployer.deployStrategies(deployment, l1PauserRegistry, cfg.Strategies, cfg.TokenRecipients); err != nil {
+			return nil, err
+		}
+	}
+
+	return deployment, nil
+}
+
+// deployer sends transactions from a single key with locally tracked nonces so the addresses of
+// contracts deployed later in the sequence can be predicted (and passed to earlier constructors)
+type deployer struct {
+	ctx     context.Context
+	chain   *BootstrapChain
+	key     *ecdsa.PrivateKey
+	from    common.Address
+	chainID *big.Int
+	nonce   uint64
+}
+
+func newDeployer(ctx context.Context, chain *BootstrapChain, key *ecdsa.PrivateKey) (*deployer, error) {
+	chainID, err := chain.Backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s chain id: %w", chain.Name, err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	nonce, err := chain.Backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s deployer nonce: %w", chain.Name, err)
+	}
+	return &deployer{ctx: ctx, chain: chain, key: key, from: from, chainID: chainID, nonce: nonce}, nil
+}
+
+// predict returns the address of the contract created by the transaction offset places after the next one
+func (d *deployer) predict(offset uint64) common.Address {
+	return crypto.CreateAddress(d.from, d.nonce+offset)
+}
+
+// send submits the transaction built by fn, mines it and checks it succeeded
+func (d *deployer) send(name string, fn func(opts *bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(d.key, d.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transactor: %w", err)
+	}
+	opts.Context = d.ctx
+	opts.Nonce = new(big.Int).SetUint64(d.nonce)
+
+	tx, err := fn(opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s on %s failed: %w", name, d.chain.Name, err)
+	}
+	d.nonce++
+
+	if err := d.chain.Mine(d.ctx); err != nil {
+		return nil, fmt.Errorf("failed to mine %s on %s: %w", name, d.chain.Name, err)
+	}
+	receipt, err := bind.WaitMined(d.ctx, d.chain.Backend, tx)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for %s on %s failed: %w", name, d.chain.Name, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%s on %s reverted (tx %s)", name, d.chain.Name, tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// deploy sends a contract creation and verifies the contract landed at the predicted address
+func (d *deployer) deploy(name string, fn func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error)) (common.Address, error) {
+	expected := d.predict(0)
+	receipt, err := d.send("deploying "+name, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		_, tx, err := fn(opts)
+		return tx, err
+	})
+	if err != nil {
+		return common.Address{}, err
+	}
+	if receipt.ContractAddress != expected {
+		return common.Address{}, fmt.Errorf("%s deployed to %s on %s, expected %s", name, receipt.ContractAddress.Hex(), d.chain.Name, expected.Hex())
+	}
+	return expected, nil
+}
+
+// deployClone deploys an EIP-1167 minimal proxy delegating to impl
+func (d *deployer) deployClone(name string, impl common.Address) (common.Address, error) {
+	return d.deploy(name+" proxy", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := bind.DeployContract(opts, abi.ABI{}, cloneCreationCode(impl), d.chain.Backend)
+		return addr, tx, err
+	})
+}
+
+func (d *deployer) deployPauserRegistry() (common.Address, error) {
+	return d.deploy("PauserRegistry", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := pauserregistry.DeployPauserRegistry(opts, d.chain.Backend, []common.Address{d.from}, d.from)
+		return addr, tx, err
+	})
+}
+
+// deployMultichain deploys the OperatorTableUpdater, certificate verifiers and TaskMailbox
+func (d *deployer) deployMultichain(pauserRegistry common.Address) (*MultichainDeployment, error) {
+	backend := d.chain.Backend
+	version := BOOTSTRAP_EIGENLAYER_VERSION
+
+	// The verifiers are bound to the OperatorTableUpdater proxy, deployed 3 transactions later
+	otuProxy := d.predict(3)
+
+	bn254CV, err := d.deploy("BN254CertificateVerifier", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := bn254certificateverifier.DeployBN254CertificateVerifier(opts, backend, otuProxy, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ecdsaCV, err := d.deploy("ECDSACertificateVerifier", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := ecdsacertificateverifier.DeployECDSACertificateVerifier(opts, backend, otuProxy, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	otuImpl, err := d.deploy("OperatorTableUpdater", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := operatortableupdater.DeployOperatorTableUpdater(opts, backend, bn254CV, ecdsaCV, pauserRegistry, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	otu, err := d.deployClone("OperatorTableUpdater", otuImpl)
+	if err != nil {
+		return nil, err
+	}
+	if otu != otuProxy {
+		return nil, fmt.Errorf("OperatorTableUpdater proxy deployed to %s, expected %s", otu.Hex(), otuProxy.Hex())
+	}
+
+	// The generator is a placeholder operator set owned by the deployer, stake roots are not generated without a transporter
+	otuContract, err := operatortableupdater.NewOperatorTableUpdater(otu, backend)
+	if err != nil {
+		return nil, err
+	}
+	generator := operatortableupdater.OperatorSet{Avs: d.from, Id: 0}
+	generatorInfo := operatortableupdater.IOperatorTableCalculatorTypesBN254OperatorSetInfo{
+		NumOperators:    big.NewInt(1),
+		AggregatePubkey: operatortableupdater.BN254G1Point{X: big.NewInt(1), Y: big.NewInt(2)},
+		TotalWeights:    []*big.Int{big.NewInt(1)},
+	}
+	if _, err := d.send("initializing OperatorTableUpdater", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return otuContract.Initialize(opts, d.from, big.NewInt(0), generator, 10000, generatorInfo)
+	}); err != nil {
+		return nil, err
+	}
+
+	mailboxImpl, err := d.deploy("TaskMailbox", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := taskmailbox.DeployTaskMailbox(opts, backend, bn254CV, ecdsaCV, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	mailbox, err := d.deployClone("TaskMailbox", mailboxImpl)
+	if err != nil {
+		return nil, err
+	}
+	mailboxContract, err := taskmailbox.NewTaskMailbox(mailbox, backend)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.send("initializing TaskMailbox", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return mailboxContract.Initialize(opts, d.from, 0, d.from)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &MultichainDeployment{
+		OperatorTableUpdater:     otu,
+		BN254CertificateVerifier: bn254CV,
+		ECDSACertificateVerifier: ecdsaCV,
+		TaskMailbox:              mailbox,
+	}, nil
+}
+
+// deployCore deploys the L1 core contracts into deployment
+func (d *deployer) deployCore(deployment *CoreDeployment, pauserRegistry common.Address, crossChainRegistryOwner common.Address) error {
+	backend := d.chain.Backend
+	version := BOOTSTRAP_EIGENLAYER_VERSION
+
+	permissionController, err := d.deploy("PermissionController", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := permissioncontroller.DeployPermissionController(opts, backend, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+
+	// The managers reference each other, so the implementations are built against the predicted proxy addresses:
+	// impls at offsets 0-3 (AM, DM, SM, EPM) followed by their proxies at offsets 4-7
+	amProxy, dmProxy, smProxy, epmProxy := d.predict(4), d.predict(5), d.predict(6), d.predict(7)
+
+	amImpl, err := d.deploy("AllocationManager", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := allocationmanager.DeployAllocationManager(opts, backend, dmProxy, common.Address{}, pauserRegistry, permissionController, BOOTSTRAP_DEALLOCATION_DELAY, BOOTSTRAP_ALLOCATION_CONFIGURATION_DELAY, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+	dmImpl, err := d.deploy("DelegationManager", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := delegationmanager.DeployDelegationManager(opts, backend, smProxy, epmProxy, amProxy, pauserRegistry, permissionController, BOOTSTRAP_MIN_WITHDRAWAL_DELAY, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+	smImpl, err := d.deploy("StrategyManager", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := strategymanager.DeployStrategyManager(opts, backend, amProxy, dmProxy, pauserRegistry, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+	epmImpl, err := d.deploy("EigenPodManager", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := eigenpodmanager.DeployEigenPodManager(opts, backend, common.Address{}, common.Address{}, dmProxy, pauserRegistry, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+
+	proxies := []struct {
+		name     string
+		impl     common.Address
+		expected common.Address
+	}{
+		{"AllocationManager", amImpl, amProxy},
+		{"DelegationManager", dmImpl, dmProxy},
+		{"StrategyManager", smImpl, smProxy},
+		{"EigenPodManager", epmImpl, epmProxy},
+	}
+	for _, p := range proxies {
+		proxy, err := d.deployClone(p.name, p.impl)
+		if err != nil {
+			return err
+		}
+		if proxy != p.expected {
+			return fmt.Errorf("%s proxy deployed to %s, expected %s", p.name, proxy.Hex(), p.expected.Hex())
+		}
+	}
+
+	// Initialize the proxies
+	am, err := allocationmanager.NewAllocationManager(amProxy, backend)
+	if err != nil {
+		return err
+	}
+	if _, err := d.send("initializing AllocationManager", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return am.Initialize(opts, big.NewInt(0))
+	}); err != nil {
+		return err
+	}
+	dm, err := delegationmanager.NewDelegationManager(dmProxy, backend)
+	if err != nil {
+		return err
+	}
+	if _, err := d.send("initializing DelegationManager", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return dm.Initialize(opts, big.NewInt(0))
+	}); err != nil {
+		return err
+	}
+	sm, err := strategymanager.NewStrategyManager(smProxy, backend)
+	if err != nil {
+		return err
+	}
+	if _, err := d.send("initializing StrategyManager", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sm.Initialize(opts, d.from, d.from, big.NewInt(0))
+	}); err != nil {
+		return err
+	}
+	epm, err := eigenpodmanager.NewEigenPodManager(epmProxy, backend)
+	if err != nil {
+		return err
+	}
+	if _, err := d.send("initializing EigenPodManager", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return epm.Initialize(opts, d.from, big.NewInt(0))
+	}); err != nil {
+		return err
+	}
+
+	keyRegistrar, err := d.deploy("KeyRegistrar", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := keyregistrar.DeployKeyRegistrar(opts, backend, permissionController, amProxy, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+
+	ccrImpl, err := d.deploy("CrossChainRegistry", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := crosschainregistry.DeployCrossChainRegistry(opts, backend, amProxy, keyRegistrar, permissionController, pauserRegistry, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+	ccrProxy, err := d.deployClone("CrossChainRegistry", ccrImpl)
+	if err != nil {
+		return err
+	}
+	ccr, err := crosschainregistry.NewCrossChainRegistry(ccrProxy, backend)
+	if err != nil {
+		return err
+	}
+	if _, err := d.send("initializing CrossChainRegistry", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return ccr.Initialize(opts, crossChainRegistryOwner, BOOTSTRAP_TABLE_UPDATE_CADENCE, big.NewInt(0))
+	}); err != nil {
+		return err
+	}
+
+	releaseManager, err := d.deploy("ReleaseManager", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := releasemanager.DeployReleaseManager(opts, backend, permissionController, version)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+
+	deployment.AllocationManager = amProxy
+	deployment.DelegationManager = dmProxy
+	deployment.StrategyManager = smProxy
+	deployment.KeyRegistrar = keyRegistrar
+	deployment.CrossChainRegistry = ccrProxy
+	deployment.ReleaseManager = releaseManager
+	deployment.PermissionController = permissionController
+	return nil
+}
+
+// deployStrategies installs a StrategyBase at each strategy address, backed by a freshly minted mock token,
+// and whitelists the strategies for deposits
+func (d *deployer) deployStrategies(deployment *CoreDeployment, pauserRegistry common.Address, strategies []common.Address, recipients []common.Address) error {
+	backend := d.chain.Backend
+
+	strategyImpl, err := d.deploy("StrategyBase", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := strategybase.DeployStrategyBase(opts, backend, deployment.StrategyManager, pauserRegistry, BOOTSTRAP_EIGENLAYER_VERSION)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+	tokenImpl, err := d.deploy("mock strategy token", func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		// The full backing supply is minted to the EIGEN address, point it at the deployer
+		addr, tx, _, err := backingeigen.DeployBackingEigen(opts, backend, d.from)
+		return addr, tx, err
+	})
+	if err != nil {
+		return err
+	}
+
+	amount := new(big.Int).Mul(big.NewInt(STRATEGY_TOKEN_FUNDING_AMOUNT_BY_LARGE_HOLDER_IN_ETH), big.NewInt(1e18))
+	for _, strategy := range strategies {
+		// Deploy and configure a mintable, freely transferable token
+		tokenAddr, err := d.deployClone("mock strategy token", tokenImpl)
+		if err != nil {
+			return err
+		}
+		token, err := backingeigen.NewBackingEigen(tokenAddr, backend)
+		if err != nil {
+			return err
+		}
+		if _, err := d.send("initializing mock strategy token", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return token.Initialize(opts, d.from)
+		}); err != nil {
+			return err
+		}
+		if _, err := d.send("disabling mock token transfer restrictions", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return token.DisableTransferRestrictions(opts)
+		}); err != nil {
+			return err
+		}
+		if _, err := d.send("setting mock token minter", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return token.SetIsMinter(opts, d.from, true)
+		}); err != nil {
+			return err
+		}
+		for _, recipient := range recipients {
+			if _, err := d.send("minting mock strategy token", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				return token.Mint(opts, recipient, amount)
+			}); err != nil {
+				return err
+			}
+		}
+
+		// Install the strategy at the address referenced in context
+		if err := d.chain.SetCode(d.ctx, strategy, cloneRuntimeCode(strategyImpl)); err != nil {
+			return fmt.Errorf("failed to install strategy at %s: %w", strategy.Hex(), err)
+		}
+		strategyContract, err := strategybase.NewStrategyBase(strategy, backend)
+		if err != nil {
+			return err
+		}
+		if _, err := d.send("initializing strategy "+strategy.Hex(), func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return strategyContract.Initialize(opts, tokenAddr)
+		}); err != nil {
+			return err
+		}
+		deployment.StrategyTokens[strategy] = tokenAddr
+	}
+
+	sm, err := strategymanager.NewStrategyManager(deployment.StrategyManager, backend)
+	if err != nil {
+		return err
+	}
+	_, err = d.send("whitelisting strategies", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sm.AddStrategiesToDepositWhitelist(opts, strategies)
+	})
+	return err
+}
+
+// cloneRuntimeCode returns the EIP-1167 minimal proxy runtime code delegating every call to impl
+func cloneRuntimeCode(impl common.Address) []byte {
+	code := common.FromHex("363d3d373d3d3d363d73")
+	code = append(code, impl.Bytes()...)
+	return append(code, common.FromHex("5af43d82803e903d91602b57fd5bf3")...)
+}
+
+// cloneCreationCode returns the creation code which deploys cloneRuntimeCode(impl)
+func cloneCreationCode(impl common.Address) []byte {
+	return append(common.FromHex("3d602d80600a3d3981f3"), cloneRuntimeCode(impl)...)
+}
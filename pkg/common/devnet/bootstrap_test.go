@@ -0,0 +1,96 @@
+package devnet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/testchain"
+	backingeigen "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/BackingEigen"
+	crosschainregistry "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/CrossChainRegistry"
+	strategybase "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyBase"
+	strategymanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/StrategyManager"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// anvilChain starts an anvil node and dials it as a BootstrapChain
+func anvilChain(t *testing.T, name string) (*BootstrapChain, *testchain.Chain) {
+	node := testchain.Start(t)
+	chain, closeChain, err := DialBootstrapChain(context.Background(), name, AnvilBackend{}, node.URL)
+	require.NoError(t, err)
+	t.Cleanup(closeChain)
+	return chain, node
+}
+
+func TestBootstrapEigenLayer(t *testing.T) {
+	key, err := crypto.HexToECDSA(ANVIL_1_KEY[2:])
+	require.NoError(t, err)
+	staker := common.HexToAddress("0x23618e81E3f5cdF7f54C3d65f7FBc0aBf5B21E8f")
+	strategy := common.HexToAddress("0x8b29d91e67b013e855EaFe0ad704aC4Ab086a574")
+	ccrOwner := common.HexToAddress("0xb094Ba769b4976Dc37fC689A76675f31bc4923b0")
+
+	l1, l1Node := anvilChain(t, "L1")
+	l2, _ := anvilChain(t, "L2")
+	l2Op, _ := anvilChain(t, "L2-OP")
+
+	deployment, err := BootstrapEigenLayer(context.Background(), l1, []*BootstrapChain{l2, l2Op}, BootstrapConfig{
+		DeployerKey:             key,
+		CrossChainRegistryOwner: ccrOwner,
+		Strategies:              []common.Address{strategy},
+		TokenRecipients:         []common.Address{staker},
+	}, logger.NewNoopLogger())
+	require.NoError(t, err)
+
+	client := l1Node.Client
+	callOpts := &bind.CallOpts{Context: context.Background()}
+
+	// Multichain contracts are deployed from the same nonces on both chains
+	assert.Equal(t, deployment.L1, deployment.L2)
+
+	// Every core contract has code
+	for name, addr := range map[string]common.Address{
+		"AllocationManager":    deployment.AllocationManager,
+		"DelegationManager":    deployment.DelegationManager,
+		"StrategyManager":      deployment.StrategyManager,
+		"KeyRegistrar":         deployment.KeyRegistrar,
+		"CrossChainRegistry":   deployment.CrossChainRegistry,
+		"ReleaseManager":       deployment.ReleaseManager,
+		"OperatorTableUpdater": deployment.L1.OperatorTableUpdater,
+		"TaskMailbox":          deployment.L1.TaskMailbox,
+	} {
+		code, err := client.CodeAt(context.Background(), addr, nil)
+		require.NoError(t, err)
+		assert.NotEmpty(t, code, name)
+	}
+
+	// The CrossChainRegistry is owned by the configured owner
+	ccr, err := crosschainregistry.NewCrossChainRegistry(deployment.CrossChainRegistry, client)
+	require.NoError(t, err)
+	owner, err := ccr.Owner(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, ccrOwner, owner)
+
+	// The strategy is whitelisted and backed by a token minted to the staker
+	sm, err := strategymanager.NewStrategyManager(deployment.StrategyManager, client)
+	require.NoError(t, err)
+	whitelisted, err := sm.StrategyIsWhitelistedForDeposit(callOpts, strategy)
+	require.NoError(t, err)
+	assert.True(t, whitelisted)
+
+	strategyContract, err := strategybase.NewStrategyBase(strategy, client)
+	require.NoError(t, err)
+	underlying, err := strategyContract.UnderlyingToken(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, deployment.StrategyTokens[strategy], underlying)
+
+	token, err := backingeigen.NewBackingEigen(underlying, client)
+	require.NoError(t, err)
+	balance, err := token.BalanceOf(callOpts, staker)
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(STRATEGY_TOKEN_FUNDING_AMOUNT_BY_LARGE_HOLDER_IN_ETH), big.NewInt(1e18)), balance)
+}
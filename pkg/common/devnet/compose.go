@@ -0,0 +1,243 @@
+package devnet
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/devkit-cli/docker/anvil"
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Labels attached to every devnet container so they can be found without parsing container names
+const (
+	ProjectLabel = "devkit.devnet.project"
+	ChainLabel   = "devkit.devnet.chain"
+	ServiceLabel = "devkit.devnet.service"
+	ContextLabel = "devkit.devnet.context"
+)
+
+// composeNetwork is the compose network shared by the chains and the extra services
+const composeNetwork = "devnet"
+
+// ComposeChain is a single chain node service in the generated docker-compose.yaml
+type ComposeChain struct {
+	// Name is the chain's key in the context (l1, l2, ...)
+	Name          string
+	ContainerName string
+	Port          int
+	// Image overrides ComposeConfig.Image for this chain
+	Image string
+	// Entrypoint and Command start the node, see ChainBackend.ContainerCommand
+	Entrypoint []string
+	Command    []string
+}
+
+// ComposeConfig describes the devnet written to docker-compose.yaml
+type ComposeConfig struct {
+	Project string
+	Context string
+	Image   string
+	Chains  []ComposeChain
+	// Services are the extra containers declared in context.services
+	Services []common.ServiceConfig
+}
+
+type composeFile struct {
+	Services map[string]composeService    `yaml:"services"`
+	Networks map[string]map[string]string `yaml:"networks"`
+	Volumes  map[string]map[string]string `yaml:"volumes,omitempty"`
+}
+
+type composeService struct {
+	Image         string            `yaml:"image"`
+	ContainerName string            `yaml:"container_name"`
+	Entrypoint    []string          `yaml:"entrypoint,omitempty"`
+	Command       []string          `yaml:"command,omitempty"`
+	Ports         []string          `yaml:"ports,omitempty"`
+	Environment   map[string]string `yaml:"environment,omitempty"`
+	Volumes       []string          `yaml:"volumes,omitempty"`
+	DependsOn     []string          `yaml:"depends_on,omitempty"`
+	Labels        map[string]string `yaml:"labels"`
+	Networks      []string          `yaml:"networks"`
+	ExtraHosts    []string          `yaml:"extra_hosts"`
+}
+
+var serviceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ChainServiceName returns the compose service name of a chain, which other services use as its hostname
+func ChainServiceName(chainName string) string {
+	return "devkit-devnet-" + chainName
+}
+
+// ServiceContainerName returns the name of the container running an extra service in a devnet namespace
+func ServiceContainerName(serviceName, namespace string) string {
+	return fmt.Sprintf("devkit-devnet-%s-%s", serviceName, namespace)
+}
+
+// GenerateDockerCompose builds the devnet docker-compose.yaml with one node service per chain followed by the
+// extra services, all attached to a single network so services can reach the chains at http://devkit-devnet-<chain>:8545
+func GenerateDockerCompose(cfg ComposeConfig) ([]byte, error) {
+	namespace := Namespace(cfg.Project, cfg.Context)
+	compose := composeFile{
+		Services: map[string]composeService{},
+		Networks: map[string]map[string]string{composeNetwork: {"name": fmt.Sprintf("devkit-devnet-%s", namespace)}},
+	}
+
+	for _, chain := range cfg.Chains {
+		image := chain.Image
+		if image == "" {
+			image = cfg.Image
+		}
+		compose.Services[ChainServiceName(chain.Name)] = composeService{
+			Image:         image,
+			ContainerName: chain.ContainerName,
+			Entrypoint:    chain.Entrypoint,
+			Command:       chain.Command,
+			Ports:         []string{fmt.Sprintf("%d:8545", chain.Port)},
+			Labels:        map[string]string{ProjectLabel: cfg.Project, ContextLabel: cfg.Context, ChainLabel: chain.Name},
+			Networks:      []string{composeNetwork},
+			ExtraHosts:    []string{"host.docker.internal:host-gateway"},
+		}
+	}
+
+	if err := ValidateServices(cfg.Services, cfg.Chains); err != nil {
+		return nil, err
+	}
+	for _, svc := range cfg.Services {
+		volumes, named, err := resolveServiceVolumes(svc.Volumes)
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", svc.Name, err)
+		}
+		for _, name := range named {
+			if compose.Volumes == nil {
+				compose.Volumes = map[string]map[string]string{}
+			}
+			compose.Volumes[name] = map[string]string{}
+		}
+
+		compose.Services[svc.Name] = composeService{
+			Image:         svc.Image,
+			ContainerName: ServiceContainerName(svc.Name, namespace),
+			Command:       svc.Command,
+			Ports:         svc.Ports,
+			Environment:   svc.Environment,
+			Volumes:       volumes,
+			DependsOn:     resolveDependsOn(svc.DependsOn, cfg.Chains),
+			Labels:        map[string]string{ProjectLabel: cfg.Project, ContextLabel: cfg.Context, ServiceLabel: svc.Name},
+			Networks:      []string{composeNetwork},
+			ExtraHosts:    []string{"host.docker.internal:host-gateway"},
+		}
+	}
+
+	out, err := yaml.Marshal(compose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate docker-compose.yaml: %w", err)
+	}
+	return out, nil
+}
+
+// ValidateServices checks the extra services have unique, well formed names which do not clash with the chains,
+// an image, and only depend on chains or other declared services
+func ValidateServices(services []common.ServiceConfig, chains []ComposeChain) error {
+	known := map[string]bool{}
+	for _, chain := range chains {
+		known[chain.Name] = true
+		known[ChainServiceName(chain.Name)] = true
+	}
+	declared := map[string]bool{}
+	for _, svc := range services {
+		if !serviceNamePattern.MatchString(svc.Name) {
+			return fmt.Errorf("invalid service name %q: use lowercase letters, digits, '-' and '_'", svc.Name)
+		}
+		if known[svc.Name] || declared[svc.Name] {
+			return fmt.Errorf("service name %q is already used by a chain or another service", svc.Name)
+		}
+		if svc.Image == "" {
+			return fmt.Errorf("service %s has no image", svc.Name)
+		}
+		declared[svc.Name] = true
+	}
+	for _, svc := range services {
+		for _, dep := range svc.DependsOn {
+			if !known[dep] && !declared[dep] {
+				return fmt.Errorf("service %s depends on unknown service or chain %q", svc.Name, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// ServiceHostPorts returns the host ports published by a service ("host:container" or "ip:host:container" entries)
+func ServiceHostPorts(svc common.ServiceConfig) []int {
+	var ports []int
+	for _, mapping := range svc.Ports {
+		parts := strings.Split(strings.Split(mapping, "/")[0], ":")
+		if len(parts) < 2 {
+			// Only a container port, docker picks a free host port
+			continue
+		}
+		if port, err := strconv.Atoi(parts[len(parts)-2]); err == nil {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// resolveDependsOn maps chain names (l1, l2, ...) in depends_on to their compose service names
+func resolveDependsOn(deps []string, chains []ComposeChain) []string {
+	resolved := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		for _, chain := range chains {
+			if dep == chain.Name {
+				dep = ChainServiceName(chain.Name)
+				break
+			}
+		}
+		resolved = append(resolved, dep)
+	}
+	return resolved
+}
+
+// resolveServiceVolumes makes bind mount sources absolute (the compose file is written outside the project)
+// and returns the named volumes which must be declared at the top level
+func resolveServiceVolumes(volumes []string) ([]string, []string, error) {
+	resolved := make([]string, 0, len(volumes))
+	var named []string
+	for _, volume := range volumes {
+		source, rest, hasTarget := strings.Cut(volume, ":")
+		switch {
+		case !hasTarget, strings.HasPrefix(source, "~"):
+			// Anonymous volumes and home relative paths are left to compose
+		case strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/"):
+			abs, err := filepath.Abs(source)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid volume %q: %w", volume, err)
+			}
+			volume = abs + ":" + rest
+		default:
+			named = append(named, source)
+		}
+		resolved = append(resolved, volume)
+	}
+	return resolved, named, nil
+}
+
+// WriteDockerCompose generates the docker-compose.yaml for the given devnet and writes it to the devnet's namespace.
+// Returns the path to the written file.
+func WriteDockerCompose(cfg ComposeConfig) (composePath string, err error) {
+	content, err := GenerateDockerCompose(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	composePath, err = assets.WriteDockerComposeToPath(Namespace(cfg.Project, cfg.Context), content)
+	if err != nil {
+		return "", fmt.Errorf("could not write docker-compose.yaml: %w", err)
+	}
+
+	return composePath, nil
+}
@@ -0,0 +1,122 @@
+package devnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testComposeChains() []ComposeChain {
+	return []ComposeChain{
+		{Name: "l1", ContainerName: "devkit-devnet-l1-demo", Port: 8545, Entrypoint: []string{"anvil"}, Command: []string{"--host", "0.0.0.0", "--chain-id", "31337"}},
+		{Name: "l2", ContainerName: "devkit-devnet-l2-demo", Port: 9545, Image: HARDHAT_IMAGE, Entrypoint: []string{"sh", "-c"}, Command: []string{"npx hardhat node"}},
+	}
+}
+
+func TestGenerateDockerComposeWithServices(t *testing.T) {
+	content, err := GenerateDockerCompose(ComposeConfig{
+		Project: "demo",
+		Context: "devnet",
+		Image:   FOUNDRY_IMAGE,
+		Chains:  testComposeChains(),
+		Services: []common.ServiceConfig{
+			{
+				Name:        "postgres",
+				Image:       "postgres:16",
+				Ports:       []string{"5432:5432"},
+				Environment: map[string]string{"POSTGRES_PASSWORD": "devkit"},
+				Volumes:     []string{"pgdata:/var/lib/postgresql/data", "./init:/docker-entrypoint-initdb.d"},
+			},
+			{
+				Name:      "oracle",
+				Image:     "example/oracle:latest",
+				Command:   []string{"--rpc", "http://devkit-devnet-l1:8545"},
+				DependsOn: []string{"l1", "postgres"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var compose composeFile
+	require.NoError(t, yaml.Unmarshal(content, &compose))
+	assert.Len(t, compose.Services, 4)
+	// Names are namespaced by project and context so devnets of several projects and contexts can run side by side
+	assert.Equal(t, map[string]string{"name": "devkit-devnet-demo-devnet"}, compose.Networks["devnet"])
+	assert.Contains(t, compose.Volumes, "pgdata")
+
+	postgres := compose.Services["postgres"]
+	assert.Equal(t, "devkit-devnet-postgres-demo-devnet", postgres.ContainerName)
+	assert.Equal(t, []string{"devnet"}, postgres.Networks)
+	assert.Equal(t, map[string]string{ProjectLabel: "demo", ContextLabel: "devnet", ServiceLabel: "postgres"}, postgres.Labels)
+	init, err := filepath.Abs("./init")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pgdata:/var/lib/postgresql/data", init + ":/docker-entrypoint-initdb.d"}, postgres.Volumes)
+
+	oracle := compose.Services["oracle"]
+	assert.Equal(t, []string{"devkit-devnet-l1", "postgres"}, oracle.DependsOn)
+	assert.Equal(t, []string{"--rpc", "http://devkit-devnet-l1:8545"}, oracle.Command)
+
+	l1 := compose.Services["devkit-devnet-l1"]
+	assert.Equal(t, []string{"anvil"}, l1.Entrypoint)
+	assert.Equal(t, FOUNDRY_IMAGE, l1.Image)
+	assert.Equal(t, []string{"--host", "0.0.0.0", "--chain-id", "31337"}, l1.Command)
+
+	// Chains on another backend run their own image
+	l2 := compose.Services["devkit-devnet-l2"]
+	assert.Equal(t, HARDHAT_IMAGE, l2.Image)
+	assert.Equal(t, []string{"sh", "-c"}, l2.Entrypoint)
+	assert.Equal(t, []string{"devnet"}, l1.Networks)
+	assert.Equal(t, map[string]string{ProjectLabel: "demo", ContextLabel: "devnet", ChainLabel: "l1"}, l1.Labels)
+}
+
+func TestValidateServices(t *testing.T) {
+	chains := testComposeChains()
+	tests := []struct {
+		name     string
+		services []common.ServiceConfig
+		errMsg   string
+	}{
+		{"valid", []common.ServiceConfig{{Name: "redis", Image: "redis:7", DependsOn: []string{"l2"}}}, ""},
+		{"invalid name", []common.ServiceConfig{{Name: "Redis", Image: "redis:7"}}, "invalid service name"},
+		{"clashes with chain", []common.ServiceConfig{{Name: "l1", Image: "redis:7"}}, "already used"},
+		{"duplicate", []common.ServiceConfig{{Name: "redis", Image: "redis:7"}, {Name: "redis", Image: "redis:7"}}, "already used"},
+		{"missing image", []common.ServiceConfig{{Name: "redis"}}, "has no image"},
+		{"unknown dependency", []common.ServiceConfig{{Name: "redis", Image: "redis:7", DependsOn: []string{"l3"}}}, "unknown service or chain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServices(tt.services, chains)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestServiceHostPorts(t *testing.T) {
+	svc := common.ServiceConfig{Ports: []string{"5432:5432", "127.0.0.1:6380:6379", "9000", "8080:80/tcp"}}
+	assert.Equal(t, []int{5432, 6380, 8080}, ServiceHostPorts(svc))
+}
+
+func TestWriteDockerComposePerNamespace(t *testing.T) {
+	chains := testComposeChains()
+	devnetPath, err := WriteDockerCompose(ComposeConfig{Project: "test-project", Context: "devnet", Image: FOUNDRY_IMAGE, Chains: chains})
+	require.NoError(t, err)
+	defer os.RemoveAll(filepath.Dir(devnetPath))
+	stagingPath, err := WriteDockerCompose(ComposeConfig{Project: "test-project", Context: "staging", Image: FOUNDRY_IMAGE, Chains: chains})
+	require.NoError(t, err)
+	defer os.RemoveAll(filepath.Dir(stagingPath))
+
+	assert.NotEqual(t, devnetPath, stagingPath)
+	assert.Contains(t, devnetPath, Namespace("test-project", "devnet"))
+	assert.FileExists(t, devnetPath)
+	assert.FileExists(t, stagingPath)
+}
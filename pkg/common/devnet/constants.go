@@ -1,7 +1,14 @@
 package devnet
 
+import "time"
+
 // Foundry Image Date : 21 April 2025
 const FOUNDRY_IMAGE = "ghcr.io/foundry-rs/foundry:stable"
+
+// Hardhat Network is installed into a plain node image when a chain runs on the hardhat backend
+const HARDHAT_IMAGE = "node:22-alpine"
+const HARDHAT_PACKAGE = "hardhat@^2.22.0"
+
 const L1_CHAIN_ARGS = "--gas-limit 140000000 --base-fee 0 --gas-price 1000000 --no-rate-limit"
 const L2_CHAIN_ARGS = "--gas-limit 140000000 --base-fee 0 --gas-price 1000000 --no-rate-limit"
 
@@ -47,3 +54,15 @@ const DEFAULT_L2_ANVIL_CHAINID = 31338
 
 const DEFAULT_L1_ANVIL_RPCURL = "http://localhost:8545"
 const DEFAULT_L2_ANVIL_RPCURL = "http://localhost:9545"
+
+// Parameters used when bootstrapping the EigenLayer core onto plain (non-forked) anvil chains
+const BOOTSTRAP_EIGENLAYER_VERSION = "1.8.0"
+const BOOTSTRAP_DEALLOCATION_DELAY = 5
+const BOOTSTRAP_ALLOCATION_CONFIGURATION_DELAY = 1
+const BOOTSTRAP_MIN_WITHDRAWAL_DELAY = 5
+const BOOTSTRAP_TABLE_UPDATE_CADENCE = 1
+
+// Defaults for the readiness probes run after the devnet containers start
+const DEFAULT_READY_TIMEOUT = 60 * time.Second
+const DEFAULT_READY_BACKOFF = 250 * time.Millisecond
+const DEFAULT_READY_MAX_BACKOFF = 2 * time.Second
@@ -0,0 +1,145 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+)
+
+// ServiceRole is the role reported for containers running an extra service rather than a chain
+const ServiceRole = "service"
+
+// DevnetContainer describes a running devnet container, derived from its docker labels and inspect data
+type DevnetContainer struct {
+	Project string `json:"project" yaml:"project"`
+	Context string `json:"context,omitempty" yaml:"context,omitempty"`
+	// Role is the chain name (l1, l2, ...) or ServiceRole
+	Role      string    `json:"role" yaml:"role"`
+	Service   string    `json:"service,omitempty" yaml:"service,omitempty"`
+	Name      string    `json:"container" yaml:"container"`
+	HostPort  int       `json:"host_port,omitempty" yaml:"host_port,omitempty"`
+	RPCURL    string    `json:"rpc_url,omitempty" yaml:"rpc_url,omitempty"`
+	Image     string    `json:"image" yaml:"image"`
+	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+	Uptime    string    `json:"uptime" yaml:"uptime"`
+}
+
+// dockerInspect holds the fields of `docker inspect` output used to describe a devnet container
+type dockerInspect struct {
+	Name   string `json:"Name"`
+	Config struct {
+		Image  string            `json:"Image"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	State struct {
+		StartedAt time.Time `json:"StartedAt"`
+	} `json:"State"`
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+// ListDevnetContainers returns every running container carrying the devnet project label
+func ListDevnetContainers(ctx context.Context) ([]DevnetContainer, error) {
+	output, err := exec.CommandContext(ctx, "docker", "ps", "-q", "--filter", "label="+ProjectLabel).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devnet containers: %w", err)
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	output, err = exec.CommandContext(ctx, "docker", append([]string{"inspect"}, ids...)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect devnet containers: %w", err)
+	}
+	return parseDevnetContainers(output, time.Now())
+}
+
+// parseDevnetContainers converts `docker inspect` output into DevnetContainers ordered by project and context, with each
+// devnet's l1 first, then its L2s and finally its services
+func parseDevnetContainers(data []byte, now time.Time) ([]DevnetContainer, error) {
+	var inspected []dockerInspect
+	if err := json.Unmarshal(data, &inspected); err != nil {
+		return nil, fmt.Errorf("failed to parse docker inspect output: %w", err)
+	}
+
+	containers := make([]DevnetContainer, 0, len(inspected))
+	for _, c := range inspected {
+		labels := c.Config.Labels
+		container := DevnetContainer{
+			Project:   labels[ProjectLabel],
+			Context:   labels[ContextLabel],
+			Name:      strings.TrimPrefix(c.Name, "/"),
+			Image:     c.Config.Image,
+			StartedAt: c.State.StartedAt,
+			Uptime:    now.Sub(c.State.StartedAt).Round(time.Second).String(),
+		}
+		if service, ok := labels[ServiceLabel]; ok {
+			container.Role = ServiceRole
+			container.Service = service
+			container.HostPort = firstHostPort(c)
+		} else {
+			container.Role = labels[ChainLabel]
+			if bindings := c.NetworkSettings.Ports["8545/tcp"]; len(bindings) > 0 {
+				container.HostPort, _ = strconv.Atoi(bindings[0].HostPort)
+			}
+			if container.HostPort != 0 {
+				container.RPCURL = GetRPCURL(container.HostPort)
+			}
+		}
+		containers = append(containers, container)
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		a, b := containers[i], containers[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Context != b.Context {
+			return a.Context < b.Context
+		}
+		if roleRank(a.Role) != roleRank(b.Role) {
+			return roleRank(a.Role) < roleRank(b.Role)
+		}
+		return a.Name < b.Name
+	})
+	return containers, nil
+}
+
+// firstHostPort returns the lowest host port published by a container, 0 if none is published
+func firstHostPort(c dockerInspect) int {
+	port := 0
+	for _, bindings := range c.NetworkSettings.Ports {
+		for _, binding := range bindings {
+			if p, err := strconv.Atoi(binding.HostPort); err == nil && (port == 0 || p < port) {
+				port = p
+			}
+		}
+	}
+	return port
+}
+
+func roleRank(role string) int {
+	switch role {
+	case common.L1:
+		return 0
+	case common.L2:
+		return 1
+	case ServiceRole:
+		return 3
+	default:
+		return 2
+	}
+}
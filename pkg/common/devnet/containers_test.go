@@ -0,0 +1,77 @@
+package devnet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInspectOutput = `[
+  {
+    "Name": "/devkit-devnet-postgres-demo",
+    "Config": {"Image": "postgres:16", "Labels": {"devkit.devnet.project": "demo", "devkit.devnet.service": "postgres"}},
+    "State": {"StartedAt": "2025-01-01T11:00:00Z"},
+    "NetworkSettings": {"Ports": {"5432/tcp": [{"HostIp": "0.0.0.0", "HostPort": "5432"}]}}
+  },
+  {
+    "Name": "/devkit-devnet-l2-demo",
+    "Config": {"Image": "ghcr.io/foundry-rs/foundry:stable", "Labels": {"devkit.devnet.project": "demo", "devkit.devnet.chain": "l2"}},
+    "State": {"StartedAt": "2025-01-01T11:59:00Z"},
+    "NetworkSettings": {"Ports": {"8545/tcp": [{"HostIp": "0.0.0.0", "HostPort": "9545"}]}}
+  },
+  {
+    "Name": "/devkit-devnet-l1-demo",
+    "Config": {"Image": "ghcr.io/foundry-rs/foundry:stable", "Labels": {"devkit.devnet.project": "demo", "devkit.devnet.chain": "l1"}},
+    "State": {"StartedAt": "2025-01-01T11:58:30Z"},
+    "NetworkSettings": {"Ports": {"8545/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8545"}]}}
+  },
+  {
+    "Name": "/devkit-devnet-l1-another",
+    "Config": {"Image": "ghcr.io/foundry-rs/foundry:stable", "Labels": {"devkit.devnet.project": "another", "devkit.devnet.chain": "l1"}},
+    "State": {"StartedAt": "2025-01-01T10:00:00Z"},
+    "NetworkSettings": {"Ports": {"8545/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8555"}]}}
+  },
+  {
+    "Name": "/devkit-devnet-l1-demo-staging",
+    "Config": {"Image": "ghcr.io/foundry-rs/foundry:stable", "Labels": {"devkit.devnet.project": "demo", "devkit.devnet.context": "staging", "devkit.devnet.chain": "l1"}},
+    "State": {"StartedAt": "2025-01-01T11:00:00Z"},
+    "NetworkSettings": {"Ports": {"8545/tcp": [{"HostIp": "127.0.0.1", "HostPort": "41234"}]}}
+  }
+]`
+
+func TestParseDevnetContainers(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	containers, err := parseDevnetContainers([]byte(testInspectOutput), now)
+	require.NoError(t, err)
+	require.Len(t, containers, 5)
+
+	// Ordered by project and context, then l1, l2 and services
+	assert.Equal(t, "devkit-devnet-l1-another", containers[0].Name)
+	assert.Equal(t, "devkit-devnet-l1-demo", containers[1].Name)
+	assert.Equal(t, "devkit-devnet-l2-demo", containers[2].Name)
+	assert.Equal(t, "devkit-devnet-postgres-demo", containers[3].Name)
+	assert.Equal(t, "devkit-devnet-l1-demo-staging", containers[4].Name)
+	assert.Equal(t, "staging", containers[4].Context)
+	assert.Equal(t, "http://localhost:41234", containers[4].RPCURL)
+
+	l1 := containers[1]
+	assert.Equal(t, "demo", l1.Project)
+	assert.Equal(t, "l1", l1.Role)
+	assert.Equal(t, 8545, l1.HostPort)
+	assert.Equal(t, "http://localhost:8545", l1.RPCURL)
+	assert.Equal(t, "ghcr.io/foundry-rs/foundry:stable", l1.Image)
+	assert.Equal(t, "1m30s", l1.Uptime)
+
+	postgres := containers[3]
+	assert.Equal(t, ServiceRole, postgres.Role)
+	assert.Equal(t, "postgres", postgres.Service)
+	assert.Equal(t, 5432, postgres.HostPort)
+	assert.Empty(t, postgres.RPCURL)
+}
+
+func TestParseDevnetContainersInvalid(t *testing.T) {
+	_, err := parseDevnetContainers([]byte("not json"), time.Now())
+	assert.Error(t, err)
+}
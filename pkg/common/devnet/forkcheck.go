@@ -0,0 +1,102 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Block tags accepted by --fork-block besides a block number
+const (
+	ForkBlockLatest    = "latest"
+	ForkBlockFinalized = "finalized"
+)
+
+// ForkContract is a contract expected to be deployed on a forked chain at the fork block
+type ForkContract struct {
+	Name    string
+	Address common.Address
+}
+
+// ParseForkBlock returns the block number in spec, and ok false when spec is the latest or finalized tag
+func ParseForkBlock(spec string) (block uint64, ok bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(spec)) {
+	case ForkBlockLatest, ForkBlockFinalized:
+		return 0, false, nil
+	}
+	block, err = strconv.ParseUint(strings.TrimSpace(spec), 10, 64)
+	if err != nil || block == 0 {
+		return 0, false, fmt.Errorf("invalid fork block %q, expected %s, %s or a block number", spec, ForkBlockLatest, ForkBlockFinalized)
+	}
+	return block, true, nil
+}
+
+// ResolveForkBlock returns the block number spec refers to on the chain served at rpcURL
+func ResolveForkBlock(ctx context.Context, rpcURL string, spec string) (uint64, error) {
+	block, ok, err := ParseForkBlock(spec)
+	if err != nil || ok {
+		return block, err
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to the fork provider: %w", err)
+	}
+	defer client.Close()
+
+	tag := big.NewInt(int64(rpc.LatestBlockNumber))
+	if strings.EqualFold(strings.TrimSpace(spec), ForkBlockFinalized) {
+		tag = big.NewInt(int64(rpc.FinalizedBlockNumber))
+	}
+	header, err := client.HeaderByNumber(ctx, tag)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the %s block from the fork provider: %w", strings.ToLower(spec), err)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// ValidateFork checks that the node at rpcURL serves state at block (0 being its head) and that every contract has code there
+func ValidateFork(ctx context.Context, rpcURL string, block uint64, contracts []ForkContract) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the fork provider: %w", err)
+	}
+	defer client.Close()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the head block from the fork provider: %w", err)
+	}
+	if block == 0 {
+		block = head
+	} else if block > head {
+		return fmt.Errorf("fork block %d is ahead of the provider's head block %d", block, head)
+	}
+	at := new(big.Int).SetUint64(block)
+
+	// Full nodes prune state older than the last 128 blocks, so only archive nodes can serve historical forks
+	if _, err := client.BalanceAt(ctx, common.Address{}, at); err != nil {
+		return fmt.Errorf("the fork provider does not serve state at block %d, forking a historical block needs an archive node: %w", block, err)
+	}
+
+	var missing []string
+	for _, contract := range contracts {
+		code, err := client.CodeAt(ctx, contract.Address, at)
+		if err != nil {
+			return fmt.Errorf("failed to read %s code at block %d: %w", contract.Name, block, err)
+		}
+		if len(code) == 0 {
+			missing = append(missing, fmt.Sprintf("%s (%s)", contract.Name, contract.Address.Hex()))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no code at block %d for %s, the block may predate the deployment or the addresses belong to another chain", block, strings.Join(missing, ", "))
+	}
+	return nil
+}
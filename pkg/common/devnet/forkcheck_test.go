@@ -0,0 +1,108 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeForkProvider serves the eth methods used to check a fork, pruning state before oldestState
+type fakeForkProvider struct {
+	head        uint64
+	finalized   uint64
+	oldestState uint64
+	// deployedAt maps contracts to the block they were deployed in
+	deployedAt map[common.Address]uint64
+}
+
+func (f *fakeForkProvider) BlockNumber() hexutil.Uint64 { return hexutil.Uint64(f.head) }
+
+func (f *fakeForkProvider) GetBlockByNumber(tag rpc.BlockNumber, full bool) *types.Header {
+	number := f.head
+	if tag == rpc.FinalizedBlockNumber {
+		number = f.finalized
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(number), Difficulty: big.NewInt(0)}
+}
+
+func (f *fakeForkProvider) GetBalance(account common.Address, block rpc.BlockNumber) (*hexutil.Big, error) {
+	if uint64(block) < f.oldestState {
+		return nil, errors.New("missing trie node")
+	}
+	return (*hexutil.Big)(big.NewInt(0)), nil
+}
+
+func (f *fakeForkProvider) GetCode(account common.Address, block rpc.BlockNumber) hexutil.Bytes {
+	deployed, ok := f.deployedAt[account]
+	if !ok || uint64(block) < deployed {
+		return hexutil.Bytes{}
+	}
+	return hexutil.Bytes{0x60, 0x80}
+}
+
+func TestParseForkBlock(t *testing.T) {
+	block, ok, err := ParseForkBlock("8836193")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(8836193), block)
+
+	_, ok, err = ParseForkBlock("Finalized")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	for _, spec := range []string{"", "0", "-1", "safe"} {
+		_, _, err = ParseForkBlock(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestResolveForkBlock(t *testing.T) {
+	url := testutils.ServeRPC(t, map[string]any{"eth": &fakeForkProvider{head: 200, finalized: 136}})
+
+	block, err := ResolveForkBlock(context.Background(), url, "latest")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), block)
+
+	block, err = ResolveForkBlock(context.Background(), url, "finalized")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(136), block)
+
+	block, err = ResolveForkBlock(context.Background(), url, "42")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), block)
+}
+
+func TestValidateFork(t *testing.T) {
+	allocationManager := common.HexToAddress("0x42583067658071247ec8CE0A516A58f682002d07")
+	url := testutils.ServeRPC(t, map[string]any{"eth": &fakeForkProvider{
+		head:        200,
+		oldestState: 50,
+		deployedAt:  map[common.Address]uint64{allocationManager: 100},
+	}})
+	contracts := []ForkContract{{Name: "allocation_manager", Address: allocationManager}}
+
+	require.NoError(t, ValidateFork(context.Background(), url, 150, contracts))
+	// 0 checks the provider's head
+	require.NoError(t, ValidateFork(context.Background(), url, 0, contracts))
+
+	err := ValidateFork(context.Background(), url, 75, contracts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allocation_manager (0x42583067658071247ec8CE0A516A58f682002d07)")
+
+	err = ValidateFork(context.Background(), url, 20, contracts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "archive node")
+
+	err = ValidateFork(context.Background(), url, 300, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ahead of the provider's head block 200")
+}
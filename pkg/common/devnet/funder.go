@@ -0,0 +1,300 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/contracts"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fundingRole is the role recorded in the FundingReport for accounts declared in the context's funding section
+const fundingRole = "funding"
+
+// holderGasBalance is the ether given to an impersonated token holder which cannot pay for its transfer
+var holderGasBalance = big.NewInt(1e18)
+
+// Funder tops up balances on a single devnet chain using its backend's dev RPCs
+type Funder struct {
+	Chain     string
+	backend   ChainBackend
+	rpcClient *rpc.Client
+	ethClient *ethclient.Client
+	erc20     abi.ABI
+	logger    iface.Logger
+	// balanceSlots caches the balances mapping discovered per token
+	balanceSlots map[common.Address]BalanceSlot
+	// missingBalanceSlots caches the tokens whose balances mapping was not found, so discovery runs once per token
+	missingBalanceSlots map[common.Address]bool
+}
+
+// NewFunder returns a Funder for the chain served by rpcClient on backend
+func NewFunder(chain string, backend ChainBackend, rpcClient *rpc.Client, logger iface.Logger) (*Funder, error) {
+	erc20, err := contracts.GetERC20ABI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC20 ABI: %w", err)
+	}
+	return &Funder{
+		Chain:               chain,
+		backend:             backend,
+		rpcClient:           rpcClient,
+		ethClient:           ethclient.NewClient(rpcClient),
+		erc20:               erc20,
+		logger:              logger,
+		balanceSlots:        map[common.Address]BalanceSlot{},
+		missingBalanceSlots: map[common.Address]bool{},
+	}, nil
+}
+
+// FundAccount applies an account's funding entry on the Funder's chain, recording each asset in the report.
+// A failed asset does not stop the account's remaining assets from being funded.
+func (f *Funder) FundAccount(ctx context.Context, account devkitcommon.FundingAccount, report *FundingReport) {
+	if !common.IsHexAddress(account.Address) {
+		report.Failed(f.Chain, account.Address, fundingRole, "-", fmt.Errorf("invalid funding address %q", account.Address))
+		return
+	}
+	address := common.HexToAddress(account.Address)
+
+	record := func(asset string, funded bool, err error) {
+		switch {
+		case err != nil:
+			report.Failed(f.Chain, address.Hex(), fundingRole, asset, err)
+		case funded:
+			report.Funded(f.Chain, address.Hex(), fundingRole, asset, "")
+		default:
+			report.Skipped(f.Chain, address.Hex(), fundingRole, asset, "balance already sufficient")
+		}
+	}
+
+	if account.ETH != "" {
+		wei, err := ParseUnits(account.ETH, 18)
+		if err != nil {
+			record("ETH", false, fmt.Errorf("invalid eth amount: %w", err))
+		} else {
+			funded, err := f.EnsureETH(ctx, address, wei)
+			record("ETH", funded, err)
+		}
+	}
+
+	for _, token := range account.Tokens {
+		funded, err := f.EnsureToken(ctx, address, token)
+		record(token.Address, funded, err)
+	}
+}
+
+// EnsureETH raises the account's ether balance to at least wei, reporting whether it had to
+func (f *Funder) EnsureETH(ctx context.Context, account common.Address, wei *big.Int) (bool, error) {
+	balance, err := f.ethClient.BalanceAt(ctx, account, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s balance of %s: %w", f.Chain, account.Hex(), err)
+	}
+	if balance.Cmp(wei) >= 0 {
+		f.logger.Info("✅ %s already holds %s wei on %s", account.Hex(), balance, f.Chain)
+		return false, nil
+	}
+
+	if err := f.backend.SetBalance(ctx, f.rpcClient, account, wei); err != nil {
+		return false, fmt.Errorf("failed to set %s balance of %s: %w", f.Chain, account.Hex(), err)
+	}
+	f.logger.Info("✅ Set %s balance of %s to %s wei", f.Chain, account.Hex(), wei)
+	return true, nil
+}
+
+// EnsureToken raises the account's balance of an ERC20 token to at least the configured amount. The balances
+// mapping is written directly, at the configured balance slot or else a discovered one, and the holder is only
+// impersonated when no slot can be found. Reports whether the balance had to be raised.
+func (f *Funder) EnsureToken(ctx context.Context, account common.Address, token devkitcommon.FundingToken) (bool, error) {
+	if !common.IsHexAddress(token.Address) {
+		return false, fmt.Errorf("invalid token address %q", token.Address)
+	}
+	tokenAddress := common.HexToAddress(token.Address)
+
+	decimals, err := f.TokenDecimals(ctx, tokenAddress)
+	if err != nil {
+		return false, err
+	}
+	target, err := ParseUnits(token.Amount, decimals)
+	if err != nil {
+		return false, fmt.Errorf("invalid amount for token %s: %w", token.Address, err)
+	}
+
+	balance, err := f.TokenBalance(ctx, tokenAddress, account)
+	if err != nil {
+		return false, err
+	}
+	if balance.Cmp(target) >= 0 {
+		f.logger.Info("✅ %s already holds %s of token %s on %s", account.Hex(), balance, tokenAddress.Hex(), f.Chain)
+		return false, nil
+	}
+
+	if token.BalanceSlot != nil {
+		err = f.setTokenBalance(ctx, tokenAddress, account, BalanceSlot{Slot: *token.BalanceSlot}, target)
+	} else {
+		var holder *common.Address
+		if token.Holder != "" {
+			if !common.IsHexAddress(token.Holder) {
+				return false, fmt.Errorf("invalid holder address %q for token %s", token.Holder, token.Address)
+			}
+			address := common.HexToAddress(token.Holder)
+			holder = &address
+		}
+		err = f.FundToken(ctx, tokenAddress, account, target, balance, holder)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	f.logger.Info("✅ Funded %s with %s of token %s on %s", account.Hex(), target, tokenAddress.Hex(), f.Chain)
+	return true, nil
+}
+
+// FundToken raises account's balance of token from balance to target by transferring from holder when one is given,
+// otherwise by writing a discovered balance slot
+func (f *Funder) FundToken(ctx context.Context, token, account common.Address, target, balance *big.Int, holder *common.Address) error {
+	if holder != nil {
+		return f.transferFromHolder(ctx, token, *holder, account, new(big.Int).Sub(target, balance))
+	}
+
+	slot, err := f.DiscoverBalanceSlot(ctx, token)
+	if err != nil {
+		return fmt.Errorf("cannot fund %s with token %s: %w; set a holder to transfer from", account.Hex(), token.Hex(), err)
+	}
+	return f.setTokenBalance(ctx, token, account, slot, target)
+}
+
+// TokenBalance returns the account's balance of an ERC20 token
+func (f *Funder) TokenBalance(ctx context.Context, token, account common.Address) (*big.Int, error) {
+	out, err := f.callToken(ctx, token, "balanceOf", account)
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected balanceOf result from token %s", token.Hex())
+	}
+	return balance, nil
+}
+
+// TokenDecimals returns the token's decimals()
+func (f *Funder) TokenDecimals(ctx context.Context, token common.Address) (uint8, error) {
+	out, err := f.callToken(ctx, token, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	decimals, ok := out[0].(uint8)
+	if !ok {
+		return 0, fmt.Errorf("unexpected decimals result from token %s", token.Hex())
+	}
+	return decimals, nil
+}
+
+func (f *Funder) callToken(ctx context.Context, token common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := f.erc20.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+	result, err := f.ethClient.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s on token %s failed: %w", method, token.Hex(), err)
+	}
+	out, err := f.erc20.Unpack(method, result)
+	if err != nil || len(out) == 0 {
+		return nil, fmt.Errorf("failed to decode %s from token %s: %v", method, token.Hex(), err)
+	}
+	return out, nil
+}
+
+// BalanceStorageKey returns the storage key of account's entry in a Solidity mapping(address => uint256) at slot
+func BalanceStorageKey(account common.Address, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(
+		common.LeftPadBytes(account.Bytes(), 32),
+		common.LeftPadBytes(new(big.Int).SetUint64(slot).Bytes(), 32),
+	)
+}
+
+// setTokenBalance writes amount into the token's balances mapping and checks balanceOf reflects it
+func (f *Funder) setTokenBalance(ctx context.Context, token, account common.Address, slot BalanceSlot, amount *big.Int) error {
+	key := slot.StorageKey(account)
+	value := common.BigToHash(amount)
+	if err := f.backend.SetStorageAt(ctx, f.rpcClient, token, key, value); err != nil {
+		return fmt.Errorf("failed to write balance slot of token %s: %w", token.Hex(), err)
+	}
+
+	balance, err := f.TokenBalance(ctx, token, account)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(amount) != 0 {
+		return fmt.Errorf("slot %d does not hold the balances of token %s (balanceOf returned %s after the write)", slot.Slot, token.Hex(), balance)
+	}
+	return nil
+}
+
+// transferFromHolder impersonates holder to transfer amount of token to account
+func (f *Funder) transferFromHolder(ctx context.Context, token, holder, account common.Address, amount *big.Int) error {
+	if _, err := f.EnsureETH(ctx, holder, holderGasBalance); err != nil {
+		return err
+	}
+	if err := f.backend.Impersonate(ctx, f.rpcClient, holder); err != nil {
+		return fmt.Errorf("failed to impersonate holder %s: %w", holder.Hex(), err)
+	}
+	defer func() {
+		if err := f.backend.StopImpersonating(ctx, f.rpcClient, holder); err != nil {
+			f.logger.Warn("Failed to stop impersonating %s: %v", holder.Hex(), err)
+		}
+	}()
+
+	data, err := contracts.PackTransferCall(account, amount)
+	if err != nil {
+		return fmt.Errorf("failed to pack transfer call: %w", err)
+	}
+	var txHash common.Hash
+	err = f.rpcClient.CallContext(ctx, &txHash, "eth_sendTransaction", map[string]interface{}{
+		"from": holder,
+		"to":   token,
+		"data": hexutil.Bytes(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to transfer token %s from holder %s: %w", token.Hex(), holder.Hex(), err)
+	}
+
+	receipt, err := bind.WaitMinedHash(ctx, f.ethClient, txHash)
+	if err != nil {
+		return fmt.Errorf("token transfer %s failed: %w", txHash.Hex(), err)
+	}
+	if receipt.Status == 0 {
+		return fmt.Errorf("token transfer %s from holder %s reverted", txHash.Hex(), holder.Hex())
+	}
+	return nil
+}
+
+// ParseUnits converts a decimal amount such as "1.5" into its integer value with the given number of decimals
+func ParseUnits(amount string, decimals uint8) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	whole, frac, _ := strings.Cut(amount, ".")
+	if whole == "" && frac == "" {
+		return nil, fmt.Errorf("empty amount")
+	}
+	if len(frac) > int(decimals) {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", amount, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || value.Sign() < 0 || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	return value, nil
+}
@@ -0,0 +1,358 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/contracts"
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFundChain serves ether balances and a single ERC20 token whose balances mapping lives at tokenSlot
+type fakeFundChain struct {
+	mu        sync.Mutex
+	token     common.Address
+	tokenSlot BalanceSlot
+	// shares makes balanceOf scale the stored value, like share based tokens such as stETH
+	shares   bool
+	balances map[common.Address]*big.Int
+	storage  map[common.Hash]common.Hash
+	// storageWrites counts the token's storage writes
+	storageWrites int
+	impersonated  map[common.Address]bool
+	receipts      map[common.Hash]*types.Receipt
+}
+
+type fakeFundEth struct{ *fakeFundChain }
+
+func (f fakeFundEth) GetBalance(account common.Address, block string) *hexutil.Big {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if balance, ok := f.balances[account]; ok {
+		return (*hexutil.Big)(balance)
+	}
+	return (*hexutil.Big)(big.NewInt(0))
+}
+
+func (f fakeFundEth) GetStorageAt(account common.Address, key common.Hash, block string) common.Hash {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.storage[key]
+}
+
+type callArgs struct {
+	To    common.Address `json:"to"`
+	Data  hexutil.Bytes  `json:"data"`
+	Input hexutil.Bytes  `json:"input"`
+}
+
+func (f fakeFundEth) Call(args callArgs, block string) (hexutil.Bytes, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	erc20, err := contracts.GetERC20ABI()
+	if err != nil {
+		return nil, err
+	}
+	data := args.Input
+	if len(data) == 0 {
+		data = args.Data
+	}
+	method, err := erc20.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "decimals":
+		return method.Outputs.Pack(uint8(6))
+	case "balanceOf":
+		in, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		value := f.storage[f.tokenSlot.StorageKey(in[0].(common.Address))].Big()
+		if f.shares {
+			value.Mul(value, big.NewInt(2))
+		}
+		return method.Outputs.Pack(value)
+	}
+	return nil, nil
+}
+
+type sendArgs struct {
+	From common.Address `json:"from"`
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// SendTransaction executes token transfers from impersonated accounts
+func (f fakeFundEth) SendTransaction(args sendArgs) (common.Hash, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.impersonated[args.From] {
+		return common.Hash{}, fmt.Errorf("%s is not impersonated", args.From.Hex())
+	}
+	erc20, err := contracts.GetERC20ABI()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	in, err := erc20.Methods["transfer"].Inputs.Unpack(args.Data[4:])
+	if err != nil {
+		return common.Hash{}, err
+	}
+	to, amount := in[0].(common.Address), in[1].(*big.Int)
+	fromKey, toKey := f.tokenSlot.StorageKey(args.From), f.tokenSlot.StorageKey(to)
+	f.storage[fromKey] = common.BigToHash(new(big.Int).Sub(f.storage[fromKey].Big(), amount))
+	f.storage[toKey] = common.BigToHash(new(big.Int).Add(f.storage[toKey].Big(), amount))
+
+	hash := common.BigToHash(big.NewInt(int64(len(f.receipts) + 1)))
+	f.receipts[hash] = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: hash, Logs: []*types.Log{}, BlockNumber: big.NewInt(1)}
+	return hash, nil
+}
+
+func (f fakeFundEth) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.receipts[hash]
+}
+
+type fakeFundAnvil struct{ *fakeFundChain }
+
+func (f fakeFundAnvil) ImpersonateAccount(account common.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.impersonated[account] = true
+}
+
+func (f fakeFundAnvil) StopImpersonatingAccount(account common.Address) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.impersonated, account)
+}
+
+func (f fakeFundAnvil) SetBalance(account common.Address, balance *hexutil.Big) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.balances[account] = (*big.Int)(balance)
+}
+
+func (f fakeFundAnvil) SetStorageAt(account common.Address, key, value common.Hash) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if account == f.token {
+		f.storage[key] = value
+		f.storageWrites++
+	}
+	return true
+}
+
+func newTestFunder(t *testing.T, chain *fakeFundChain) *Funder {
+	client := dialFakeChain(t, map[string]any{"eth": fakeFundEth{chain}, "anvil": fakeFundAnvil{chain}})
+	funder, err := NewFunder("l1", AnvilBackend{}, client, logger.NewNoopLogger())
+	require.NoError(t, err)
+	return funder
+}
+
+func newFakeFundChain() *fakeFundChain {
+	return &fakeFundChain{
+		token:        common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		tokenSlot:    BalanceSlot{Slot: 9},
+		balances:     map[common.Address]*big.Int{},
+		storage:      map[common.Hash]common.Hash{},
+		impersonated: map[common.Address]bool{},
+		receipts:     map[common.Hash]*types.Receipt{},
+	}
+}
+
+func TestFundAccountETH(t *testing.T) {
+	chain := newFakeFundChain()
+	funder := newTestFunder(t, chain)
+	rich := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	poor := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	chain.balances[rich] = new(big.Int).Mul(big.NewInt(50), big.NewInt(1e18))
+
+	report := &FundingReport{}
+	for _, account := range []common.Address{rich, poor} {
+		funder.FundAccount(context.Background(), devkitcommon.FundingAccount{Address: account.Hex(), ETH: "2.5"}, report)
+	}
+	require.NoError(t, report.Err())
+
+	// Balances above the target are left alone
+	assert.Equal(t, "50000000000000000000", chain.balances[rich].String())
+	assert.Equal(t, "2500000000000000000", chain.balances[poor].String())
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, FundingSkipped, report.Results[0].Status)
+	assert.Equal(t, FundingFunded, report.Results[1].Status)
+}
+
+func TestFundAccountTokenBalanceSlot(t *testing.T) {
+	chain := newFakeFundChain()
+	funder := newTestFunder(t, chain)
+	account := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	slot := uint64(9)
+
+	report := &FundingReport{}
+	funder.FundAccount(context.Background(), devkitcommon.FundingAccount{
+		Address: account.Hex(),
+		Tokens:  []devkitcommon.FundingToken{{Address: chain.token.Hex(), Amount: "1000", BalanceSlot: &slot}},
+	}, report)
+	require.NoError(t, report.Err())
+
+	balance, err := funder.TokenBalance(context.Background(), chain.token, account)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000", balance.String())
+}
+
+func TestFundAccountTokenErrors(t *testing.T) {
+	chain := newFakeFundChain()
+	funder := newTestFunder(t, chain)
+	account := common.HexToAddress("0x0000000000000000000000000000000000000004")
+	wrongSlot := uint64(0)
+
+	report := &FundingReport{}
+	funder.FundAccount(context.Background(), devkitcommon.FundingAccount{
+		Address: account.Hex(),
+		Tokens:  []devkitcommon.FundingToken{{Address: chain.token.Hex(), Amount: "1", BalanceSlot: &wrongSlot}},
+	}, report)
+	err := report.Err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slot 0 does not hold the balances")
+
+	// Without a discoverable slot a holder is required
+	chain.shares = true
+	report = &FundingReport{}
+	funder.FundAccount(context.Background(), devkitcommon.FundingAccount{
+		Address: account.Hex(),
+		Tokens:  []devkitcommon.FundingToken{{Address: chain.token.Hex(), Amount: "1"}},
+	}, report)
+	err = report.Err()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrBalanceSlotNotFound)
+	assert.Contains(t, err.Error(), "set a holder to transfer from")
+
+	report = &FundingReport{}
+	funder.FundAccount(context.Background(), devkitcommon.FundingAccount{Address: "not-an-address", ETH: "1"}, report)
+	err = report.Err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid funding address")
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		want     string
+		wantErr  bool
+	}{
+		{"1", 18, "1000000000000000000", false},
+		{"0.5", 18, "500000000000000000", false},
+		{"1000", 6, "1000000000", false},
+		{".25", 2, "25", false},
+		{"1.234", 2, "", true},
+		{"-1", 18, "", true},
+		{"abc", 18, "", true},
+		{"", 18, "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseUnits(tt.amount, tt.decimals)
+		if tt.wantErr {
+			assert.Error(t, err, tt.amount)
+			continue
+		}
+		require.NoError(t, err, tt.amount)
+		assert.Equal(t, tt.want, got.String(), tt.amount)
+	}
+}
+
+func TestFundAccountTokenDiscoversSlot(t *testing.T) {
+	chain := newFakeFundChain()
+	funder := newTestFunder(t, chain)
+	account := common.HexToAddress("0x0000000000000000000000000000000000000005")
+
+	report := &FundingReport{}
+	funder.FundAccount(context.Background(), devkitcommon.FundingAccount{
+		Address: account.Hex(),
+		Tokens:  []devkitcommon.FundingToken{{Address: chain.token.Hex(), Amount: "2.5"}},
+	}, report)
+	require.NoError(t, report.Err())
+
+	balance, err := funder.TokenBalance(context.Background(), chain.token, account)
+	require.NoError(t, err)
+	assert.Equal(t, "2500000", balance.String())
+}
+
+func TestFundAccountTokenFromHolder(t *testing.T) {
+	chain := newFakeFundChain()
+	funder := newTestFunder(t, chain)
+	account := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	holder := common.HexToAddress("0x0000000000000000000000000000000000000006")
+	chain.storage[chain.tokenSlot.StorageKey(holder)] = common.BigToHash(big.NewInt(10_000_000))
+
+	report := &FundingReport{}
+	funder.FundAccount(context.Background(), devkitcommon.FundingAccount{
+		Address: account.Hex(),
+		Tokens:  []devkitcommon.FundingToken{{Address: chain.token.Hex(), Amount: "2.5", Holder: holder.Hex()}},
+	}, report)
+	require.NoError(t, report.Err())
+
+	// An explicit holder is transferred from rather than writing the balance slot
+	balance, err := funder.TokenBalance(context.Background(), chain.token, account)
+	require.NoError(t, err)
+	assert.Equal(t, "2500000", balance.String())
+	balance, err = funder.TokenBalance(context.Background(), chain.token, holder)
+	require.NoError(t, err)
+	assert.Equal(t, "7500000", balance.String())
+	assert.Empty(t, chain.impersonated)
+}
+
+func TestDiscoverBalanceSlot(t *testing.T) {
+	for _, want := range []BalanceSlot{{Slot: 0}, {Slot: 51}, {Slot: 3, Vyper: true}} {
+		chain := newFakeFundChain()
+		chain.tokenSlot = want
+		funder := newTestFunder(t, chain)
+
+		slot, err := funder.DiscoverBalanceSlot(context.Background(), chain.token)
+		require.NoError(t, err)
+		assert.Equal(t, want, slot)
+
+		// Every probe is restored
+		for key, value := range chain.storage {
+			assert.Equal(t, common.Hash{}, value, "slot %s left modified", key.Hex())
+		}
+	}
+}
+
+func TestDiscoverBalanceSlotNotFound(t *testing.T) {
+	chain := newFakeFundChain()
+	chain.shares = true
+	funder := newTestFunder(t, chain)
+
+	_, err := funder.DiscoverBalanceSlot(context.Background(), chain.token)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrBalanceSlotNotFound)
+
+	// The miss is cached, so the token is not probed again
+	writes := chain.storageWrites
+	_, err = funder.DiscoverBalanceSlot(context.Background(), chain.token)
+	assert.ErrorIs(t, err, ErrBalanceSlotNotFound)
+	assert.Equal(t, writes, chain.storageWrites)
+}
+
+func TestDiscoverBalanceSlotShareBased(t *testing.T) {
+	chain := newFakeFundChain()
+	chain.token = common.HexToAddress(ST_ETH_TOKEN_ADDRESS)
+	funder := newTestFunder(t, chain)
+
+	// Share based tokens with a known holder are not probed
+	_, err := funder.DiscoverBalanceSlot(context.Background(), chain.token)
+	assert.ErrorIs(t, err, ErrBalanceSlotNotFound)
+	assert.Zero(t, chain.storageWrites)
+}
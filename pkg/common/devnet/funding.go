@@ -3,6 +3,7 @@ package devnet
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
@@ -30,14 +31,18 @@ type TokenFunding struct {
 	TokenName     string         `json:"token_name"`
 	HolderAddress common.Address `json:"holder_address"`
 	Amount        *big.Int       `json:"amount"`
+	// ShareBased marks tokens whose balances are computed from shares, so no storage slot holds them
+	ShareBased bool `json:"share_based"`
 }
 
-// Common Sepolia token holders with large balances - mapped by token address
+// Common Sepolia token holders with large balances - mapped by token address.
+// Only used for tokens whose balance slot cannot be discovered.
 var DefaultTokenHolders = map[common.Address]TokenFunding{
 	common.HexToAddress(ST_ETH_TOKEN_ADDRESS): { // stETH token address
 		TokenName:     "stETH",
 		HolderAddress: common.HexToAddress("0xC8088abD2FdaF4819230EB0FdA2D9766FDF9F409"),                                    // Large stETH holder
 		Amount:        new(big.Int).Mul(big.NewInt(STRATEGY_TOKEN_FUNDING_AMOUNT_BY_LARGE_HOLDER_IN_ETH), big.NewInt(1e18)), // 1000 tokens
+		ShareBased:    true,
 	},
 	common.HexToAddress(B_EIGEN_TOKEN_ADDRESS): { // bEIGEN token address
 		TokenName:     "bEIGEN",
@@ -47,7 +52,7 @@ var DefaultTokenHolders = map[common.Address]TokenFunding{
 }
 
 // FundStakerWithTokens funds staker with strategy tokens using impersonation
-func FundStakerWithTokens(ctx context.Context, ethClient *ethclient.Client, rpcClient *rpc.Client, stakerAddress common.Address, tokenFunding TokenFunding, tokenAddress common.Address, rpcURL string) error {
+func FundStakerWithTokens(ctx context.Context, backend ChainBackend, ethClient *ethclient.Client, rpcClient *rpc.Client, stakerAddress common.Address, tokenFunding TokenFunding, tokenAddress common.Address, rpcURL string) error {
 	if tokenFunding.TokenName == "bEIGEN" {
 		// For bEIGEN, we need to call unwrap() on the EIGEN contract first
 		// to convert EIGEN tokens to bEIGEN tokens
@@ -59,7 +64,7 @@ func FundStakerWithTokens(ctx context.Context, ethClient *ethclient.Client, rpcC
 		}
 
 		// Start impersonating the token holder for unwrap call
-		if err := devkitcommon.ImpersonateAccount(rpcClient, tokenFunding.HolderAddress); err != nil {
+		if err := backend.Impersonate(ctx, rpcClient, tokenFunding.HolderAddress); err != nil {
 			return fmt.Errorf("failed to impersonate token holder for unwrap: %w", err)
 		}
 
@@ -83,7 +88,7 @@ func FundStakerWithTokens(ctx context.Context, ethClient *ethclient.Client, rpcC
 		// if holder balance < 0.1 ether, fund it
 		fundValue, _ := strconv.ParseInt(FUND_VALUE, 10, 64)
 		if balance.Cmp(big.NewInt(fundValue)) < 0 {
-			err = fundIfNeeded(ethClient, tokenFunding.HolderAddress, ANVIL_2_KEY)
+			_, err = fundIfNeeded(ethClient, tokenFunding.HolderAddress, ANVIL_2_KEY)
 			if err != nil {
 				return fmt.Errorf("failed to fund holder address: %w", err)
 			}
@@ -115,7 +120,7 @@ func FundStakerWithTokens(ctx context.Context, ethClient *ethclient.Client, rpcC
 		}
 
 		// Stop impersonating for unwrap (we'll impersonate again for transfer)
-		if err := devkitcommon.StopImpersonatingAccount(rpcClient, tokenFunding.HolderAddress); err != nil {
+		if err := backend.StopImpersonating(ctx, rpcClient, tokenFunding.HolderAddress); err != nil {
 			log.Printf("⚠️  Failed to stop impersonating after unwrap %s: %v", tokenFunding.HolderAddress.Hex(), err)
 		}
 	} else if tokenFunding.TokenName == "stETH" {
@@ -130,7 +135,7 @@ func FundStakerWithTokens(ctx context.Context, ethClient *ethclient.Client, rpcC
 		anvil1Address := crypto.PubkeyToAddress(privateKey.PublicKey)
 
 		// Start impersonating the token holder
-		if err := devkitcommon.ImpersonateAccount(rpcClient, anvil1Address); err != nil {
+		if err := backend.Impersonate(ctx, rpcClient, anvil1Address); err != nil {
 			return fmt.Errorf("failed to impersonate token holder: %w", err)
 		}
 
@@ -210,7 +215,7 @@ func FundStakerWithTokens(ctx context.Context, ethClient *ethclient.Client, rpcC
 		}
 
 		// Stop impersonating for transfer
-		if err := devkitcommon.StopImpersonatingAccount(rpcClient, anvil1Address); err != nil {
+		if err := backend.StopImpersonating(ctx, rpcClient, anvil1Address); err != nil {
 			log.Printf("⚠️  Failed to stop impersonating after transfer %s: %v", anvil1Address.Hex(), err)
 		}
 	}
@@ -218,26 +223,37 @@ func FundStakerWithTokens(ctx context.Context, ethClient *ethclient.Client, rpcC
 	return nil
 }
 
-// FundStakersWithStrategyTokens funds all stakers with the specified strategy tokens
-func FundStakersWithStrategyTokens(cfg *devkitcommon.ConfigWithContextConfig, rpcURL string, tokenAddresses []string) error {
+// FundStakersWithStrategyTokens funds all stakers with the specified strategy tokens, recording each
+// staker and token in the report and carrying on past failures
+func FundStakersWithStrategyTokens(cfg *devkitcommon.ConfigWithContextConfig, rpcURL string, tokenAddresses []string, logger iface.Logger) (*FundingReport, error) {
+	report := &FundingReport{}
 	if os.Getenv("SKIP_TOKEN_FUNDING") == "true" {
-		log.Println("🔧 Skipping token funding (test mode)")
-		return nil
+		logger.Info("🔧 Skipping token funding (test mode)")
+		return report, nil
 	}
 
 	// Connect to RPC
 	rpcClient, err := rpc.Dial(rpcURL)
 	if err != nil {
-		return fmt.Errorf("failed to connect to RPC: %w", err)
+		return nil, fmt.Errorf("failed to connect to RPC: %w", err)
 	}
 	defer rpcClient.Close()
 
 	ethClient, err := ethclient.Dial(rpcURL)
 	if err != nil {
-		return fmt.Errorf("failed to connect to ETH client: %w", err)
+		return nil, fmt.Errorf("failed to connect to ETH client: %w", err)
 	}
 	defer ethClient.Close()
 
+	backend, err := ChainBackendFor(devkitcommon.L1, cfg.Context[DEVNET_CONTEXT].Chains[devkitcommon.L1])
+	if err != nil {
+		return nil, err
+	}
+	funder, err := NewFunder(devkitcommon.L1, backend, rpcClient, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
 
 	// Fund each staker with each requested token
@@ -246,22 +262,63 @@ func FundStakersWithStrategyTokens(cfg *devkitcommon.ConfigWithContextConfig, rp
 
 		for _, tokenAddressStr := range tokenAddresses {
 			tokenAddress := common.HexToAddress(tokenAddressStr)
-			tokenFunding, exists := DefaultTokenHolders[tokenAddress]
-
-			if !exists {
-				log.Printf("Unknown token address: %s, skipping", tokenAddress.Hex())
-				continue
+			funded, err := fundStakerWithStrategyToken(ctx, funder, ethClient, rpcClient, stakerAddr, tokenAddress, rpcURL)
+			switch {
+			case err != nil:
+				report.Failed(devkitcommon.L1, stakerAddr.Hex(), "staker", tokenAddress.Hex(), err)
+			case funded:
+				report.Funded(devkitcommon.L1, stakerAddr.Hex(), "staker", tokenAddress.Hex(), "")
+			default:
+				report.Skipped(devkitcommon.L1, stakerAddr.Hex(), "staker", tokenAddress.Hex(), "balance already sufficient")
 			}
+		}
+	}
 
-			err := FundStakerWithTokens(ctx, ethClient, rpcClient, stakerAddr, tokenFunding, tokenAddress, rpcURL)
-			if err != nil {
-				log.Printf("❌ Failed to fund %s with %s (%s): %v", stakerAddr.Hex(), tokenFunding.TokenName, tokenAddressStr, err)
-				continue
-			}
+	return report, nil
+}
+
+// fundStakerWithStrategyToken mints the staker's token balance by writing the token's discovered balance slot,
+// falling back to the large holders in DefaultTokenHolders for tokens whose balances cannot be located (e.g. stETH).
+// Returns false when the staker already held enough.
+func fundStakerWithStrategyToken(ctx context.Context, funder *Funder, ethClient *ethclient.Client, rpcClient *rpc.Client, staker, token common.Address, rpcURL string) (bool, error) {
+	decimals, err := funder.TokenDecimals(ctx, token)
+	if err != nil {
+		return false, err
+	}
+	amount := new(big.Int).Mul(
+		big.NewInt(STRATEGY_TOKEN_FUNDING_AMOUNT_BY_LARGE_HOLDER_IN_ETH),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil),
+	)
+
+	balance, err := funder.TokenBalance(ctx, token, staker)
+	if err != nil {
+		return false, err
+	}
+	if balance.Cmp(amount) >= 0 {
+		return false, nil
+	}
+
+	slot, err := funder.DiscoverBalanceSlot(ctx, token)
+	if err == nil {
+		if err := funder.setTokenBalance(ctx, token, staker, slot, amount); err != nil {
+			return false, err
 		}
+		funder.logger.Info("✅ Minted %s of token %s to %s through balance slot %d", amount, token.Hex(), staker.Hex(), slot.Slot)
+		return true, nil
+	}
+	if !errors.Is(err, ErrBalanceSlotNotFound) {
+		return false, err
 	}
 
-	return nil
+	tokenFunding, ok := DefaultTokenHolders[token]
+	if !ok {
+		return false, fmt.Errorf("%w and no known holder to transfer from", err)
+	}
+	funder.logger.Info("No balance slot found for %s, falling back to funding from holder %s", tokenFunding.TokenName, tokenFunding.HolderAddress.Hex())
+	if err := FundStakerWithTokens(ctx, funder.backend, ethClient, rpcClient, staker, tokenFunding, token, rpcURL); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // waitForTransaction waits for a transaction to be mined
@@ -287,102 +344,119 @@ func waitForTransaction(ctx context.Context, client *ethclient.Client, txHash co
 	}
 }
 
-// FundWallets sends ETH to a list of addresses
-// Only funds wallets with balance < 0.3 ether.
-func FundWalletsDevnet(cfg *devkitcommon.ConfigWithContextConfig, rpcURL string) error {
+// FundWalletsDevnet sends ETH to the operator and transporter wallets on the given chain.
+// Only funds wallets with balance < 0.3 ether. An account which cannot be funded (unreadable keystore,
+// invalid key, failed transfer) is recorded in the report and the remaining accounts are still funded.
+func FundWalletsDevnet(cfg *devkitcommon.ConfigWithContextConfig, chainName, rpcURL string) (*FundingReport, error) {
+	report := &FundingReport{}
 	if os.Getenv("SKIP_DEVNET_FUNDING") == "true" {
 		log.Println("🔧 Skipping devnet wallet funding (test mode)")
-		return nil
+		return report, nil
 	}
 
 	ethClient, err := ethclient.Dial(rpcURL)
 	if err != nil {
-		return fmt.Errorf("failed to connect to ETH client: %w", err)
+		return nil, fmt.Errorf("failed to connect to ETH client: %w", err)
 	}
 	defer ethClient.Close()
 
 	// All operator keys from [operator]
 	// We only intend to fund for devnet, so hardcoding to `CONTEXT` is fine
 	for _, operator := range cfg.Context[DEVNET_CONTEXT].Operators {
-		var privateKey *ecdsa.PrivateKey
-
-		// Check if ECDSA keystore is configured
-		if len(operator.Keystores) > 0 && operator.Keystores[0].ECDSAKeystorePath != "" && operator.Keystores[0].ECDSAKeystorePassword != "" {
-			// Load from keystore
-			keystoreData, err := os.ReadFile(operator.Keystores[0].ECDSAKeystorePath)
-			if err != nil {
-				log.Fatalf("failed to read ECDSA keystore file %s: %v", operator.Keystores[0].ECDSAKeystorePath, err)
-			}
-
-			key, err := keystore.DecryptKey(keystoreData, operator.Keystores[0].ECDSAKeystorePassword)
-			if err != nil {
-				log.Fatalf("failed to decrypt ECDSA keystore: %v", err)
-			}
-
-			privateKey = key.PrivateKey
-		} else if operator.ECDSAKey != "" {
-			// Fall back to plaintext key
-			cleanedKey := strings.TrimPrefix(operator.ECDSAKey, "0x")
-			var err error
-			privateKey, err = crypto.HexToECDSA(cleanedKey)
-			if err != nil {
-				log.Fatalf("invalid private key %q: %v", operator.ECDSAKey, err)
-			}
-		} else {
-			log.Fatalf("no ECDSA key configuration found for operator %s", operator.Address)
-		}
-		err = fundIfNeeded(ethClient, crypto.PubkeyToAddress(privateKey.PublicKey), ANVIL_2_KEY)
+		privateKey, err := operatorFundingKey(operator)
 		if err != nil {
-			return err
+			report.Failed(chainName, operator.Address, "operator", "ETH", err)
+			continue
 		}
+		fundWallet(report, ethClient, chainName, crypto.PubkeyToAddress(privateKey.PublicKey), "operator")
 	}
 
 	// Fund transporter
 	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.Context[DEVNET_CONTEXT].Transporter.PrivateKey, "0x"))
 	if err != nil {
-		return fmt.Errorf("failed to parse private key: %w", err)
+		report.Failed(chainName, "transporter", "transporter", "ETH", fmt.Errorf("failed to parse private key: %w", err))
+	} else {
+		fundWallet(report, ethClient, chainName, crypto.PubkeyToAddress(privateKey.PublicKey), "transporter")
 	}
 
-	err = fundIfNeeded(ethClient, crypto.PubkeyToAddress(privateKey.PublicKey), ANVIL_2_KEY)
-	if err != nil {
-		return err
+	return report, nil
+}
+
+// operatorFundingKey loads the operator's ECDSA key from its first keystore, falling back to the plaintext key
+func operatorFundingKey(operator devkitcommon.OperatorSpec) (*ecdsa.PrivateKey, error) {
+	// Check if ECDSA keystore is configured
+	if len(operator.Keystores) > 0 && operator.Keystores[0].ECDSAKeystorePath != "" && operator.Keystores[0].ECDSAKeystorePassword != "" {
+		// Load from keystore
+		keystoreData, err := os.ReadFile(operator.Keystores[0].ECDSAKeystorePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ECDSA keystore file %s: %w", operator.Keystores[0].ECDSAKeystorePath, err)
+		}
+
+		key, err := keystore.DecryptKey(keystoreData, operator.Keystores[0].ECDSAKeystorePassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt ECDSA keystore: %w", err)
+		}
+		return key.PrivateKey, nil
 	}
 
-	return nil
+	if operator.ECDSAKey != "" {
+		// Fall back to plaintext key
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(operator.ECDSAKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key for operator %s: %w", operator.Address, err)
+		}
+		return privateKey, nil
+	}
+
+	return nil, fmt.Errorf("no ECDSA key configuration found for operator %s", operator.Address)
 }
 
-func fundIfNeeded(ethClient *ethclient.Client, to common.Address, fromKey string) error {
+// fundWallet funds a single wallet from ANVIL_2_KEY and records the outcome
+func fundWallet(report *FundingReport, ethClient *ethclient.Client, chainName string, address common.Address, role string) {
+	funded, err := fundIfNeeded(ethClient, address, ANVIL_2_KEY)
+	switch {
+	case err != nil:
+		report.Failed(chainName, address.Hex(), role, "ETH", err)
+	case funded:
+		report.Funded(chainName, address.Hex(), role, "ETH", fmt.Sprintf("sent %s wei", FUND_VALUE))
+	default:
+		report.Skipped(chainName, address.Hex(), role, "ETH", "balance already above 0.3 ETH")
+	}
+}
+
+// fundIfNeeded sends FUND_VALUE to the account when its balance is below 0.3 ether, reporting whether it did
+func fundIfNeeded(ethClient *ethclient.Client, to common.Address, fromKey string) (bool, error) {
 	balance, err := ethClient.BalanceAt(context.Background(), to, nil)
 	if err != nil {
 		log.Printf(" Please check if your L1 and L2 fork rpc url is up")
-		return fmt.Errorf("failed to get balance for account %s %v", to.String(), err)
+		return false, fmt.Errorf("failed to get balance for account %s %v", to.String(), err)
 	}
 	threshold := new(big.Int)
 	threshold.SetString("300000000000000000", 10) // 0.3 ETH in wei
 
 	if balance.Cmp(threshold) >= 0 {
 		log.Printf("✅ %s already has sufficient balance (%s wei)", to, balance.String())
-		return nil
+		return false, nil
 	}
 
 	value, _ := new(big.Int).SetString(FUND_VALUE, 10) // 1 ETH in wei
 	gasPrice, err := ethClient.SuggestGasPrice(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to get gas price: %w", err)
+		return false, fmt.Errorf("failed to get gas price: %w", err)
 	}
 
 	// Get the nonce for the sender
 	fromKey = strings.TrimPrefix(fromKey, "0x")
 	privateKey, err := crypto.HexToECDSA(fromKey)
 	if err != nil {
-		return fmt.Errorf("failed to parse private key: %w", err)
+		return false, fmt.Errorf("failed to parse private key: %w", err)
 	}
 	fromAddress := crypto.PubkeyToAddress(privateKey.PublicKey)
 
 	// Check sender's balance
 	senderBalance, err := ethClient.BalanceAt(context.Background(), fromAddress, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get sender balance: %w", err)
+		return false, fmt.Errorf("failed to get sender balance: %w", err)
 	}
 
 	// Calculate total cost (value + gas)
@@ -391,12 +465,12 @@ func fundIfNeeded(ethClient *ethclient.Client, to common.Address, fromKey string
 	totalCost.Add(totalCost, value)
 
 	if senderBalance.Cmp(totalCost) < 0 {
-		return fmt.Errorf("funder has insufficient balance: has %s wei, needs %s wei", senderBalance.String(), totalCost.String())
+		return false, fmt.Errorf("funder has insufficient balance: has %s wei, needs %s wei", senderBalance.String(), totalCost.String())
 	}
 
 	nonce, err := ethClient.PendingNonceAt(context.Background(), fromAddress)
 	if err != nil {
-		return fmt.Errorf("failed to get nonce: %w", err)
+		return false, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
 	tx := types.NewTransaction(
@@ -411,19 +485,19 @@ func fundIfNeeded(ethClient *ethclient.Client, to common.Address, fromKey string
 	// Get chain ID
 	chainID, err := ethClient.ChainID(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to get chain ID: %w", err)
+		return false, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
 	// Sign the transaction with the latest signer
 	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
 	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %w", err)
+		return false, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
 	err = ethClient.SendTransaction(context.Background(), signedTx)
 	if err != nil {
 		log.Printf("Failed to send eth funding transaction: %v", err)
-		return fmt.Errorf("failed to send transaction: %w", err)
+		return false, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
 	log.Printf("Transaction sent, waiting for confirmation...")
@@ -431,15 +505,15 @@ func fundIfNeeded(ethClient *ethclient.Client, to common.Address, fromKey string
 	// Wait for transaction to be mined using bind.WaitMined
 	receipt, err := bind.WaitMined(context.Background(), ethClient, signedTx)
 	if err != nil {
-		return fmt.Errorf("failed to wait for transaction: %w", err)
+		return false, fmt.Errorf("failed to wait for transaction: %w", err)
 	}
 
 	if receipt.Status == 0 {
-		return fmt.Errorf("transaction failed")
+		return false, fmt.Errorf("transaction failed")
 	}
 
 	log.Printf("✅ Funded %s (tx: %s)", to, signedTx.Hash().Hex())
-	return nil
+	return true, nil
 }
 
 // GetUnderlyingTokenAddressesFromStrategies extracts all unique underlying token addresses from strategy contracts
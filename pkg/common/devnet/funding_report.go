@@ -0,0 +1,90 @@
+package devnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// FundingStatus is the outcome of funding a single account
+type FundingStatus string
+
+const (
+	FundingFunded  FundingStatus = "funded"
+	FundingSkipped FundingStatus = "skipped"
+	FundingFailed  FundingStatus = "failed"
+)
+
+// FundingError is the failure to fund a single account on one chain
+type FundingError struct {
+	Chain string
+	// Account is the funded address, or a description of the entry when the address could not be derived
+	Account string
+	Err     error
+}
+
+func (e *FundingError) Error() string {
+	return fmt.Sprintf("funding %s on %s: %v", e.Account, e.Chain, e.Err)
+}
+
+func (e *FundingError) Unwrap() error {
+	return e.Err
+}
+
+// FundingResult records what happened to one account
+type FundingResult struct {
+	Chain   string
+	Account string
+	// Role describes why the account is funded (operator, transporter, staker, funding)
+	Role   string
+	Asset  string
+	Status FundingStatus
+	Detail string
+}
+
+// FundingReport collects the per account results of a funding run, which carries on past failed accounts
+type FundingReport struct {
+	Results []FundingResult
+	errs    []error
+}
+
+// Funded records an account which was topped up
+func (r *FundingReport) Funded(chain, account, role, asset, detail string) {
+	r.Results = append(r.Results, FundingResult{Chain: chain, Account: account, Role: role, Asset: asset, Status: FundingFunded, Detail: detail})
+}
+
+// Skipped records an account which needed no funding
+func (r *FundingReport) Skipped(chain, account, role, asset, detail string) {
+	r.Results = append(r.Results, FundingResult{Chain: chain, Account: account, Role: role, Asset: asset, Status: FundingSkipped, Detail: detail})
+}
+
+// Failed records an account which could not be funded
+func (r *FundingReport) Failed(chain, account, role, asset string, err error) {
+	r.Results = append(r.Results, FundingResult{Chain: chain, Account: account, Role: role, Asset: asset, Status: FundingFailed, Detail: err.Error()})
+	r.errs = append(r.errs, &FundingError{Chain: chain, Account: account, Err: err})
+}
+
+// Merge appends the results and errors of other
+func (r *FundingReport) Merge(other *FundingReport) {
+	r.Results = append(r.Results, other.Results...)
+	r.errs = append(r.errs, other.errs...)
+}
+
+// Err joins the FundingError of every failed account, nil when all accounts were funded
+func (r *FundingReport) Err() error {
+	return errors.Join(r.errs...)
+}
+
+// WriteSummary prints a table with one row per result
+func (r *FundingReport) WriteSummary(w io.Writer) error {
+	if len(r.Results) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHAIN\tACCOUNT\tROLE\tASSET\tSTATUS\tDETAIL")
+	for _, result := range r.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", result.Chain, result.Account, result.Role, result.Asset, result.Status, result.Detail)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,106 @@
+package devnet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	devkitcommon "github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFundingReportErr(t *testing.T) {
+	report := &FundingReport{}
+	report.Funded("l1", "0x01", "operator", "ETH", "")
+	report.Skipped("l2", "0x01", "operator", "ETH", "")
+	require.NoError(t, report.Err())
+
+	cause := errors.New("keystore missing")
+	other := &FundingReport{}
+	other.Failed("l2", "0x02", "operator", "ETH", cause)
+	report.Merge(other)
+
+	err := report.Err()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, cause)
+
+	var fundingErr *FundingError
+	require.True(t, errors.As(err, &fundingErr))
+	assert.Equal(t, "l2", fundingErr.Chain)
+	assert.Equal(t, "0x02", fundingErr.Account)
+	assert.Len(t, report.Results, 3)
+}
+
+func TestFundingReportWriteSummary(t *testing.T) {
+	report := &FundingReport{}
+	report.Funded("l1", "0x01", "operator", "ETH", "sent 1 wei")
+	report.Failed("l1", "0x02", "transporter", "ETH", errors.New("bad key"))
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteSummary(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "CHAIN")
+	assert.Contains(t, out, "funded")
+	assert.Contains(t, out, "failed")
+	assert.Contains(t, out, "bad key")
+
+	// An empty report prints nothing
+	buf.Reset()
+	require.NoError(t, (&FundingReport{}).WriteSummary(&buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestFundWalletsDevnetContinuesPastBrokenOperator(t *testing.T) {
+	chain := newFakeFundChain()
+	url := testutils.ServeRPC(t, map[string]any{"eth": fakeFundEth{chain}})
+
+	operatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	transporterKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// Wallets above the threshold are skipped, so no transfers are needed from the fake chain
+	rich := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))
+	chain.balances[crypto.PubkeyToAddress(operatorKey.PublicKey)] = rich
+	chain.balances[crypto.PubkeyToAddress(transporterKey.PublicKey)] = rich
+
+	cfg := &devkitcommon.ConfigWithContextConfig{
+		Context: map[string]devkitcommon.ChainContextConfig{
+			DEVNET_CONTEXT: {
+				Transporter: devkitcommon.Transporter{PrivateKey: fmt.Sprintf("0x%x", crypto.FromECDSA(transporterKey))},
+				Operators: []devkitcommon.OperatorSpec{
+					{
+						Address: "0xbroken",
+						Keystores: []devkitcommon.OperatorKeystores{{
+							ECDSAKeystorePath:     filepath.Join(t.TempDir(), "missing.json"),
+							ECDSAKeystorePassword: "secret",
+						}},
+					},
+					{Address: "0xhealthy", ECDSAKey: fmt.Sprintf("0x%x", crypto.FromECDSA(operatorKey))},
+				},
+			},
+		},
+	}
+
+	report, err := FundWalletsDevnet(cfg, "l1", url)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 3)
+
+	assert.Equal(t, FundingFailed, report.Results[0].Status)
+	assert.Equal(t, "0xbroken", report.Results[0].Account)
+	assert.Equal(t, FundingSkipped, report.Results[1].Status)
+	assert.Equal(t, crypto.PubkeyToAddress(operatorKey.PublicKey).Hex(), report.Results[1].Account)
+	assert.Equal(t, FundingSkipped, report.Results[2].Status)
+	assert.Equal(t, "transporter", report.Results[2].Role)
+
+	var fundingErr *FundingError
+	require.True(t, errors.As(report.Err(), &fundingErr))
+	assert.Equal(t, "0xbroken", fundingErr.Account)
+	assert.Contains(t, fundingErr.Error(), "failed to read ECDSA keystore file")
+}
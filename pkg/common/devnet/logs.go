@@ -0,0 +1,272 @@
+package devnet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+)
+
+// LogsDir is the project relative directory `devnet start --save-logs` writes a timestamped folder of logs into
+var LogsDir = filepath.Join(".devkit", "logs")
+
+// AVSComponents are the offchain AVS processes started by `devkit avs run` whose containers can be tailed
+var AVSComponents = []string{"aggregator", "executor"}
+
+// logsPollInterval is how often RecordDevnetLogs looks for containers which started after it
+const logsPollInterval = 2 * time.Second
+
+// LogSource is a container whose logs can be tailed, under the name accepted by `devnet logs --service`
+type LogSource struct {
+	Service   string
+	Container string
+}
+
+// DevnetLogSources returns the running chain and service containers of the project's devnet for the context,
+// followed by the containers of the AVS components
+func DevnetLogSources(ctx context.Context, projectName, contextName string) ([]LogSource, error) {
+	containers, err := ListDevnetContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []LogSource
+	for _, c := range containers {
+		if c.Project != projectName || (c.Context != "" && c.Context != contextName) {
+			continue
+		}
+		service := c.Role
+		if c.Role == ServiceRole {
+			service = c.Service
+		}
+		sources = append(sources, LogSource{Service: service, Container: c.Name})
+	}
+
+	for _, component := range AVSComponents {
+		output, err := exec.CommandContext(ctx, "docker", "ps", "--filter", "name="+component, "--format", "{{.Names}}").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s containers: %w", component, err)
+		}
+		for _, name := range matchComponentContainers(component, projectName, strings.Fields(string(output))) {
+			sources = append(sources, LogSource{Service: component, Container: name})
+		}
+	}
+	return sources, nil
+}
+
+// matchComponentContainers picks the containers running an AVS component, preferring the ones named after the
+// project when several projects run the same component
+func matchComponentContainers(component, projectName string, names []string) []string {
+	var matched, named []string
+	for _, name := range names {
+		if !strings.Contains(name, component) {
+			continue
+		}
+		matched = append(matched, name)
+		if strings.Contains(name, projectName) {
+			named = append(named, name)
+		}
+	}
+	if len(named) > 0 {
+		return named
+	}
+	return matched
+}
+
+// SelectLogSources keeps the sources of the named services, or all of them when none are named
+func SelectLogSources(sources []LogSource, services []string) ([]LogSource, error) {
+	if len(services) == 0 {
+		return sources, nil
+	}
+
+	var available []string
+	for _, source := range sources {
+		if !slices.Contains(available, source.Service) {
+			available = append(available, source.Service)
+		}
+	}
+	for _, service := range services {
+		if !slices.Contains(available, service) {
+			return nil, fmt.Errorf("no running container for service %q, expected one of %v", service, available)
+		}
+	}
+
+	var selected []LogSource
+	for _, source := range sources {
+		if slices.Contains(services, source.Service) {
+			selected = append(selected, source)
+		}
+	}
+	return selected, nil
+}
+
+// StreamLogs writes the docker logs of each source to w, every line prefixed with its service. With follow it
+// keeps streaming until ctx is done. tail limits the lines shown per container ("all" for everything).
+func StreamLogs(ctx context.Context, w io.Writer, sources []LogSource, follow bool, tail string) error {
+	width := 0
+	for _, source := range sources {
+		width = max(width, len(source.Service))
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(sources))
+	for i, source := range sources {
+		args := []string{"logs", "--timestamps", "--tail", tail}
+		if follow {
+			args = append(args, "--follow")
+		}
+		out := &prefixedLineWriter{w: w, mu: &mu, prefix: fmt.Sprintf("%s%-*s |%s ", Cyan, width, source.Service, Reset)}
+
+		cmd := exec.CommandContext(ctx, "docker", append(args, source.Container)...)
+		cmd.Stdout = out
+		cmd.Stderr = out
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := cmd.Run(); err != nil && ctx.Err() == nil {
+				errs[i] = fmt.Errorf("failed to read logs of %s: %w", source.Container, err)
+			}
+			out.Flush()
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordDevnetLogs follows the logs of every devnet and AVS component container into dir/<service>.log until ctx is
+// done, attaching to containers as they start
+func RecordDevnetLogs(ctx context.Context, logger iface.Logger, dir, projectName, contextName string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create log dir: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	attached := map[string]bool{}
+	// Files are named after the service, with the container added when a service has several containers
+	files := map[string]bool{}
+	ticker := time.NewTicker(logsPollInterval)
+	defer ticker.Stop()
+	for {
+		sources, err := DevnetLogSources(ctx, projectName, contextName)
+		if err != nil && ctx.Err() == nil {
+			logger.Warn("Failed to list devnet containers for logging: %v", err)
+		}
+		for _, source := range sources {
+			if attached[source.Container] {
+				continue
+			}
+			attached[source.Container] = true
+
+			fileName := source.Service + ".log"
+			if files[fileName] {
+				fileName = fmt.Sprintf("%s-%s.log", source.Service, source.Container)
+			}
+			files[fileName] = true
+
+			file, err := os.OpenFile(filepath.Join(dir, fileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return fmt.Errorf("failed to create log file for %s: %w", source.Container, err)
+			}
+
+			// Not bound to ctx so the containers' final lines are kept when the devnet shuts down
+			cmd := exec.Command("docker", "logs", "--timestamps", "--follow", source.Container)
+			cmd.Stdout = file
+			cmd.Stderr = file
+			if err := cmd.Start(); err != nil {
+				_ = file.Close()
+				logger.Warn("Failed to follow logs of %s: %v", source.Container, err)
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer file.Close()
+				done := make(chan struct{})
+				go func() {
+					_ = cmd.Wait()
+					close(done)
+				}()
+				select {
+				case <-done:
+				case <-ctx.Done():
+					// Give docker a moment to flush what the container printed while stopping
+					select {
+					case <-done:
+					case <-time.After(logsPollInterval):
+						_ = cmd.Process.Kill()
+						<-done
+					}
+				}
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// prefixedLineWriter writes complete lines to w behind a prefix, holding mu so lines of concurrent writers do not interleave
+type prefixedLineWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix string
+	// bufMu guards buf, a container's stdout and stderr are copied in by separate goroutines
+	bufMu sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (p *prefixedLineWriter) Write(data []byte) (int, error) {
+	p.bufMu.Lock()
+	defer p.bufMu.Unlock()
+	p.buf.Write(data)
+	for {
+		line, err := p.buf.ReadBytes('\n')
+		if err != nil {
+			// Keep the partial line for the next write
+			p.buf.Write(line)
+			return len(data), nil
+		}
+		p.writeLine(line)
+	}
+}
+
+// Flush writes out a trailing line which did not end in a newline
+func (p *prefixedLineWriter) Flush() {
+	p.bufMu.Lock()
+	defer p.bufMu.Unlock()
+	if p.buf.Len() > 0 {
+		line := append(p.buf.Bytes(), '\n')
+		p.buf.Reset()
+		p.writeLine(line)
+	}
+}
+
+func (p *prefixedLineWriter) writeLine(line []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = io.WriteString(p.w, p.prefix)
+	_, _ = p.w.Write(line)
+}
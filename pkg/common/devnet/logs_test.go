@@ -0,0 +1,55 @@
+package devnet
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchComponentContainers(t *testing.T) {
+	names := []string{"aggregator", "my-avs-aggregator", "other-avs-aggregator", "executor"}
+
+	// Containers named after the project win when several projects run the component
+	assert.Equal(t, []string{"my-avs-aggregator"}, matchComponentContainers("aggregator", "my-avs", names))
+	assert.Equal(t, []string{"aggregator", "my-avs-aggregator", "other-avs-aggregator"}, matchComponentContainers("aggregator", "third-avs", names))
+	assert.Equal(t, []string{"executor"}, matchComponentContainers("executor", "my-avs", names))
+	assert.Empty(t, matchComponentContainers("executor", "my-avs", nil))
+}
+
+func TestSelectLogSources(t *testing.T) {
+	sources := []LogSource{
+		{Service: "l1", Container: "devkit-devnet-l1-demo-devnet"},
+		{Service: "l2", Container: "devkit-devnet-l2-demo-devnet"},
+		{Service: "aggregator", Container: "aggregator"},
+	}
+
+	all, err := SelectLogSources(sources, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sources, all)
+
+	selected, err := SelectLogSources(sources, []string{"aggregator", "l1"})
+	require.NoError(t, err)
+	assert.Equal(t, []LogSource{sources[0], sources[2]}, selected)
+
+	_, err = SelectLogSources(sources, []string{"executor"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no running container for service "executor"`)
+}
+
+func TestPrefixedLineWriter(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+	l1 := &prefixedLineWriter{w: &out, mu: &mu, prefix: "l1 | "}
+	l2 := &prefixedLineWriter{w: &out, mu: &mu, prefix: "l2 | "}
+
+	// Partial lines are held until they are complete, so lines of different sources never interleave
+	_, _ = l1.Write([]byte("block 1 mi"))
+	_, _ = l2.Write([]byte("block 7\nblock 8\n"))
+	_, _ = l1.Write([]byte("ned\nblock 2"))
+	l1.Flush()
+
+	assert.Equal(t, "l2 | block 7\nl2 | block 8\nl1 | block 1 mined\nl1 | block 2\n", out.String())
+}
@@ -0,0 +1,143 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ReadinessConfig controls how long and how often WaitForChains polls the devnet
+type ReadinessConfig struct {
+	// Timeout bounds the wait for each chain
+	Timeout time.Duration
+	// InitialBackoff is the delay after the first failed probe, doubling up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultReadinessConfig returns the readiness settings used when none are configured
+func DefaultReadinessConfig() ReadinessConfig {
+	return ReadinessConfig{
+		Timeout:        DEFAULT_READY_TIMEOUT,
+		InitialBackoff: DEFAULT_READY_BACKOFF,
+		MaxBackoff:     DEFAULT_READY_MAX_BACKOFF,
+	}
+}
+
+// ChainProbe describes a devnet chain to wait for
+type ChainProbe struct {
+	Name   string
+	RPCURL string
+	// ChainID is the chain id the node must report, 0 accepts any
+	ChainID uint64
+	// MinBlock is the lowest acceptable head (the fork block for forked chains), 0 accepts any
+	MinBlock uint64
+}
+
+// WaitForChains polls eth_chainId and eth_blockNumber on every chain until each reports the expected chain id
+// and has reached its fork block, reporting progress per chain through tracker
+func WaitForChains(ctx context.Context, probes []ChainProbe, cfg ReadinessConfig, tracker iface.ProgressTracker) error {
+	// Trackers are not safe for concurrent use
+	var mu sync.Mutex
+	report := func(probe ChainProbe, pct int, label string) {
+		mu.Lock()
+		defer mu.Unlock()
+		tracker.Set(probe.Name, pct, fmt.Sprintf("%s %s", probe.Name, label))
+		tracker.Render()
+	}
+
+	errs := make([]error, len(probes))
+	var wg sync.WaitGroup
+	for i, probe := range probes {
+		wg.Add(1)
+		go func(i int, probe ChainProbe) {
+			defer wg.Done()
+			errs[i] = waitForChain(ctx, probe, cfg, report)
+		}(i, probe)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// waitForChain polls a single chain with exponential backoff until it is ready or cfg.Timeout elapses
+func waitForChain(ctx context.Context, probe ChainProbe, cfg ReadinessConfig, report func(ChainProbe, int, string)) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	report(probe, 0, "waiting for rpc")
+
+	client, err := rpc.DialContext(ctx, probe.RPCURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s rpc %s: %w", probe.Name, probe.RPCURL, err)
+	}
+	defer client.Close()
+
+	backoff := cfg.InitialBackoff
+	var lastErr error
+	for {
+		done, err := probeChain(ctx, client, probe, report)
+		if done {
+			report(probe, 100, "ready")
+			return nil
+		}
+		if err != nil {
+			var mismatch *chainIDMismatchError
+			if errors.As(err, &mismatch) {
+				return err
+			}
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("%s devnet not ready after %s: %w", probe.Name, cfg.Timeout, lastErr)
+			}
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+}
+
+// chainIDMismatchError is returned when the node reports a different chain, retrying will not help
+type chainIDMismatchError struct {
+	name         string
+	want, actual uint64
+}
+
+func (e *chainIDMismatchError) Error() string {
+	return fmt.Sprintf("%s devnet reports chain id %d, expected %d", e.name, e.actual, e.want)
+}
+
+// probeChain runs one round of readiness checks, returning true once the chain is ready
+func probeChain(ctx context.Context, client *rpc.Client, probe ChainProbe, report func(ChainProbe, int, string)) (bool, error) {
+	var chainID hexutil.Uint64
+	if err := client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		return false, fmt.Errorf("eth_chainId failed: %w", err)
+	}
+	if probe.ChainID != 0 && uint64(chainID) != probe.ChainID {
+		return false, &chainIDMismatchError{name: probe.Name, want: probe.ChainID, actual: uint64(chainID)}
+	}
+	report(probe, 50, fmt.Sprintf("chain id %d", uint64(chainID)))
+
+	var blockNumber hexutil.Uint64
+	if err := client.CallContext(ctx, &blockNumber, "eth_blockNumber"); err != nil {
+		return false, fmt.Errorf("eth_blockNumber failed: %w", err)
+	}
+	if uint64(blockNumber) < probe.MinBlock {
+		return false, fmt.Errorf("head at block %d, waiting for fork block %d", uint64(blockNumber), probe.MinBlock)
+	}
+	return true, nil
+}
@@ -0,0 +1,99 @@
+package devnet
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain reports a fixed chain id and a head that advances by one block per eth_blockNumber call
+type fakeChain struct {
+	chainID uint64
+	head    atomic.Uint64
+}
+
+func (f *fakeChain) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(f.chainID)
+}
+
+func (f *fakeChain) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(f.head.Add(1))
+}
+
+// dialFakeChain returns an RPC client connected to testutils.ServeRPC
+func dialFakeChain(t *testing.T, namespaces map[string]any) *rpc.Client {
+	client, err := rpc.Dial(testutils.ServeRPC(t, namespaces))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+// recordingTracker keeps the last progress reported per id
+type recordingTracker struct {
+	mu   sync.Mutex
+	last map[string]int
+}
+
+func (r *recordingTracker) ProgressRows() []iface.ProgressRow { return nil }
+func (r *recordingTracker) Render()                           {}
+func (r *recordingTracker) Clear()                            {}
+func (r *recordingTracker) Set(id string, pct int, label string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last[id] = pct
+}
+
+func testReadinessConfig() ReadinessConfig {
+	return ReadinessConfig{Timeout: 2 * time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestWaitForChains(t *testing.T) {
+	l1 := &fakeChain{chainID: 31337}
+	l2 := &fakeChain{chainID: 31338}
+	tracker := &recordingTracker{last: map[string]int{}}
+
+	err := WaitForChains(context.Background(), []ChainProbe{
+		{Name: "l1", RPCURL: testutils.ServeRPC(t, map[string]any{"eth": l1}), ChainID: 31337, MinBlock: 5},
+		{Name: "l2", RPCURL: testutils.ServeRPC(t, map[string]any{"eth": l2}), ChainID: 31338},
+	}, testReadinessConfig(), tracker)
+	require.NoError(t, err)
+
+	// L1 is polled until its head reaches the fork block, L2 is ready on the first probe
+	assert.Equal(t, uint64(5), l1.head.Load())
+	assert.Equal(t, uint64(1), l2.head.Load())
+	assert.Equal(t, map[string]int{"l1": 100, "l2": 100}, tracker.last)
+}
+
+func TestWaitForChainsChainIDMismatch(t *testing.T) {
+	chain := &fakeChain{chainID: 1}
+	tracker := &recordingTracker{last: map[string]int{}}
+
+	err := WaitForChains(context.Background(), []ChainProbe{
+		{Name: "l1", RPCURL: testutils.ServeRPC(t, map[string]any{"eth": chain}), ChainID: 31337},
+	}, testReadinessConfig(), tracker)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reports chain id 1, expected 31337")
+}
+
+func TestWaitForChainsTimeout(t *testing.T) {
+	// Nothing listens on the url, so every probe fails until the timeout
+	cfg := testReadinessConfig()
+	cfg.Timeout = 50 * time.Millisecond
+	tracker := &recordingTracker{last: map[string]int{}}
+
+	err := WaitForChains(context.Background(), []ChainProbe{
+		{Name: "l1", RPCURL: "http://127.0.0.1:1"},
+	}, cfg, tracker)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "l1 devnet not ready after 50ms")
+	assert.Equal(t, 0, tracker.last["l1"])
+}
@@ -0,0 +1,283 @@
+package devnet
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// RPCCacheDir is the project relative directory holding cached fork RPC responses
+var RPCCacheDir = filepath.Join(".devkit", "rpc-cache")
+
+// cacheableRPCMethods are the methods whose result is fixed once the block they refer to is pinned
+var cacheableRPCMethods = map[string]bool{
+	"eth_chainId":                  true,
+	"net_version":                  true,
+	"eth_getBalance":               true,
+	"eth_getCode":                  true,
+	"eth_getTransactionCount":      true,
+	"eth_getStorageAt":             true,
+	"eth_getProof":                 true,
+	"eth_call":                     true,
+	"eth_getBlockByNumber":         true,
+	"eth_getBlockByHash":           true,
+	"eth_getTransactionByHash":     true,
+	"eth_getTransactionReceipt":    true,
+	"eth_getBlockReceipts":         true,
+	"eth_getUncleCountByBlockHash": true,
+}
+
+// movingBlockTags are block parameters whose meaning changes over time, requests using them are never cached
+var movingBlockTags = []string{`"latest"`, `"pending"`, `"safe"`, `"finalized"`}
+
+// RPCCachePath returns the cache directory for a chain forked from upstream at the given block.
+// The upstream URL is hashed into the path, so forks of different networks at the same block never share responses
+func RPCCachePath(chainName string, upstream string, forkBlock int) string {
+	sum := sha256.Sum256([]byte(upstream))
+	return filepath.Join(RPCCacheDir, chainName, hex.EncodeToString(sum[:8]), fmt.Sprintf("%d", forkBlock))
+}
+
+// ForkCacheProxyAddr returns the address the fork cache proxy listens on: the docker bridge gateway when the
+// containers reach the host through it (Linux), loopback otherwise, so the keyed upstream is never exposed to the LAN
+func ForkCacheProxyAddr() string {
+	if ip := net.ParseIP(GetDockerHost()); ip != nil {
+		return net.JoinHostPort(ip.String(), "0")
+	}
+	return "127.0.0.1:0"
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// ForkCacheProxy is a JSON-RPC proxy which persists deterministic upstream responses on disk,
+// so repeated forks of the same pinned block are served locally (and offline once warmed)
+type ForkCacheProxy struct {
+	upstream string
+	dir      string
+	client   *http.Client
+	listener net.Listener
+	server   *http.Server
+	hits     atomic.Int64
+	misses   atomic.Int64
+}
+
+// StartForkCacheProxy serves a caching proxy for upstream on addr (see ForkCacheProxyAddr), storing responses in dir
+func StartForkCacheProxy(upstream string, dir string, addr string) (*ForkCacheProxy, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create rpc cache dir: %w", err)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for rpc cache proxy: %w", err)
+	}
+
+	p := &ForkCacheProxy{
+		upstream: upstream,
+		dir:      dir,
+		client:   &http.Client{Timeout: 60 * time.Second},
+		listener: listener,
+	}
+	p.server = &http.Server{Handler: p, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = p.server.Serve(listener) }()
+	return p, nil
+}
+
+// Port returns the port the proxy is listening on
+func (p *ForkCacheProxy) Port() int {
+	return p.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Stats returns the number of requests served from cache and from upstream
+func (p *ForkCacheProxy) Stats() (hits, misses int64) {
+	return p.hits.Load(), p.misses.Load()
+}
+
+// Close stops the proxy
+func (p *ForkCacheProxy) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return p.server.Shutdown(ctx)
+}
+
+// ServeHTTP handles single and batched JSON-RPC requests
+func (p *ForkCacheProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	var out interface{}
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []rpcRequest
+		if err := json.Unmarshal(trimmed, &reqs); err != nil {
+			http.Error(w, "invalid json-rpc batch", http.StatusBadRequest)
+			return
+		}
+		resps := make([]rpcResponse, 0, len(reqs))
+		for _, req := range reqs {
+			resps = append(resps, p.handle(r.Context(), req))
+		}
+		out = resps
+	} else {
+		var req rpcRequest
+		if err := json.Unmarshal(trimmed, &req); err != nil {
+			http.Error(w, "invalid json-rpc request", http.StatusBadRequest)
+			return
+		}
+		out = p.handle(r.Context(), req)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// handle answers a single request from cache, falling back to upstream
+func (p *ForkCacheProxy) handle(ctx context.Context, req rpcRequest) rpcResponse {
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+
+	cacheable := isCacheableRequest(req)
+	var path string
+	if cacheable {
+		path = filepath.Join(p.dir, cacheKey(req)+".json")
+		if result, err := os.ReadFile(path); err == nil {
+			p.hits.Add(1)
+			resp.Result = result
+			return resp
+		}
+	}
+
+	p.misses.Add(1)
+	upstreamResp, err := p.forward(ctx, req)
+	if err != nil {
+		resp.Error = &rpcError{Code: -32603, Message: fmt.Sprintf("fork cache: upstream unavailable and response not cached: %v", err)}
+		return resp
+	}
+	resp.Result = upstreamResp.Result
+	resp.Error = upstreamResp.Error
+
+	// Persist successful results only, errors may be transient
+	if cacheable && upstreamResp.Error == nil && len(upstreamResp.Result) > 0 && string(upstreamResp.Result) != "null" {
+		_ = writeFileAtomic(path, upstreamResp.Result)
+	}
+	return resp
+}
+
+// forward sends a single request upstream
+func (p *ForkCacheProxy) forward(ctx context.Context, req rpcRequest) (*rpcResponse, error) {
+	req.JSONRPC = "2.0"
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.upstream, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upstream returned %s", httpResp.Status)
+	}
+
+	var resp rpcResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("invalid upstream response: %w", err)
+	}
+	return &resp, nil
+}
+
+// isCacheableRequest reports whether the response to req cannot change over time
+func isCacheableRequest(req rpcRequest) bool {
+	if !cacheableRPCMethods[req.Method] {
+		return false
+	}
+	params := string(req.Params)
+	for _, tag := range movingBlockTags {
+		if strings.Contains(params, tag) {
+			return false
+		}
+	}
+	// State reads without an explicit block default to latest
+	switch req.Method {
+	case "eth_getBalance", "eth_getCode", "eth_getTransactionCount", "eth_call":
+		return paramCount(req.Params) >= 2
+	case "eth_getStorageAt", "eth_getProof":
+		return paramCount(req.Params) >= 3
+	}
+	return true
+}
+
+// paramCount returns the number of positional params
+func paramCount(raw json.RawMessage) int {
+	var params []json.RawMessage
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return 0
+	}
+	return len(params)
+}
+
+// cacheKey identifies a request by method and params
+func cacheKey(req rpcRequest) string {
+	var params bytes.Buffer
+	if err := json.Compact(&params, req.Params); err != nil {
+		params.Write(req.Params)
+	}
+	sum := sha256.Sum256([]byte(req.Method + ":" + strings.ToLower(params.String())))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFileAtomic writes data to path via a temp file so concurrent readers never see partial entries
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
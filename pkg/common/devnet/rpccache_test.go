@@ -0,0 +1,112 @@
+package devnet
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUpstream serves a few eth_* methods and counts the calls it receives
+type fakeUpstream struct {
+	calls atomic.Int64
+}
+
+func (f *fakeUpstream) GetBalance(addr string, block string) string {
+	f.calls.Add(1)
+	return "0x64"
+}
+
+func (f *fakeUpstream) BlockNumber() string {
+	f.calls.Add(1)
+	return "0x10"
+}
+
+func TestForkCacheProxy(t *testing.T) {
+	upstream := &fakeUpstream{}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", upstream))
+	httpServer := httptest.NewServer(server)
+
+	dir := t.TempDir()
+	ctx := context.Background()
+	addr := "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+
+	proxy, err := StartForkCacheProxy(httpServer.URL, dir, "127.0.0.1:0")
+	require.NoError(t, err)
+	client, err := rpc.DialContext(ctx, GetRPCURL(proxy.Port()))
+	require.NoError(t, err)
+
+	// Pinned block reads are fetched once then served from cache
+	var balance string
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.CallContext(ctx, &balance, "eth_getBalance", addr, "0x10"))
+		assert.Equal(t, "0x64", balance)
+	}
+	assert.Equal(t, int64(1), upstream.calls.Load())
+
+	// Moving block tags and non-deterministic methods always go upstream
+	require.NoError(t, client.CallContext(ctx, &balance, "eth_getBalance", addr, "latest"))
+	var blockNumber string
+	require.NoError(t, client.CallContext(ctx, &blockNumber, "eth_blockNumber"))
+	assert.Equal(t, int64(3), upstream.calls.Load())
+
+	hits, misses := proxy.Stats()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(3), misses)
+
+	client.Close()
+	require.NoError(t, proxy.Close())
+
+	// Once warmed, a new proxy over the same dir answers without the upstream
+	httpServer.Close()
+	proxy, err = StartForkCacheProxy(httpServer.URL, dir, "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = proxy.Close() }()
+	client, err = rpc.DialContext(ctx, GetRPCURL(proxy.Port()))
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, client.CallContext(ctx, &balance, "eth_getBalance", addr, "0x10"))
+	assert.Equal(t, "0x64", balance)
+	assert.Error(t, client.CallContext(ctx, &blockNumber, "eth_blockNumber"))
+}
+
+func TestIsCacheableRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		params string
+		want   bool
+	}{
+		{"eth_getStorageAt", `["0x1","0x0","0x10"]`, true},
+		{"eth_getStorageAt", `["0x1","0x0"]`, false},
+		{"eth_getCode", `["0x1","latest"]`, false},
+		{"eth_getBlockByNumber", `["0x10",false]`, true},
+		{"eth_blockNumber", `[]`, false},
+		{"eth_sendRawTransaction", `["0x00"]`, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isCacheableRequest(rpcRequest{Method: tt.method, Params: []byte(tt.params)}), tt.method+tt.params)
+	}
+}
+
+func TestRPCCachePath(t *testing.T) {
+	mainnet := RPCCachePath("l1", "https://mainnet.example/v2/key", 100)
+	holesky := RPCCachePath("l1", "https://holesky.example/v2/key", 100)
+
+	assert.NotEqual(t, mainnet, holesky)
+	assert.Equal(t, mainnet, RPCCachePath("l1", "https://mainnet.example/v2/key", 100))
+	assert.NotContains(t, mainnet, "key")
+}
+
+func TestForkCacheProxyAddr(t *testing.T) {
+	t.Setenv("DOCKERS_HOST", "172.17.0.1")
+	assert.Equal(t, "172.17.0.1:0", ForkCacheProxyAddr())
+
+	t.Setenv("DOCKERS_HOST", "host.docker.internal")
+	assert.Equal(t, "127.0.0.1:0", ForkCacheProxyAddr())
+}
@@ -0,0 +1,121 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SnapshotsDir is the project relative directory holding devnet snapshots
+var SnapshotsDir = filepath.Join(".devkit", "snapshots")
+
+const (
+	// SnapshotMetadataFile describes the snapshot
+	SnapshotMetadataFile = "snapshot.json"
+	// SnapshotContextFile is the copy of the context yaml taken alongside the chain state
+	SnapshotContextFile = "context.yaml"
+	// SnapshotStateFile is the copy of the devnet state file (completed setup steps)
+	SnapshotStateFile = "devnet-state.json"
+)
+
+var snapshotNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// SnapshotChain records the chain state captured for a single chain
+type SnapshotChain struct {
+	ChainID     uint64 `json:"chain_id"`
+	BlockNumber uint64 `json:"block_number"`
+	Timestamp   uint64 `json:"timestamp"`
+	StateFile   string `json:"state_file"`
+}
+
+// SnapshotMetadata describes a snapshot on disk
+type SnapshotMetadata struct {
+	Name      string                   `json:"name"`
+	Context   string                   `json:"context"`
+	Project   string                   `json:"project"`
+	CreatedAt time.Time                `json:"created_at"`
+	Chains    map[string]SnapshotChain `json:"chains"`
+}
+
+// ValidateSnapshotName ensures the snapshot name is safe to use as a directory name
+func ValidateSnapshotName(name string) error {
+	if !snapshotNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// SnapshotPath returns the directory holding the named snapshot
+func SnapshotPath(name string) string {
+	return filepath.Join(SnapshotsDir, name)
+}
+
+// SnapshotChainStateFile returns the file name used for a chain's dumped state
+func SnapshotChainStateFile(chainName string) string {
+	return fmt.Sprintf("%s.state", chainName)
+}
+
+// DumpChainState returns the state blob produced by the chain backend's DumpState
+func DumpChainState(ctx context.Context, backend ChainBackend, rpcURL string) (string, error) {
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	state, err := backend.DumpState(ctx, client)
+	if err != nil {
+		return "", fmt.Errorf("%s on %s: %w", backend.Name(), rpcURL, err)
+	}
+	return state, nil
+}
+
+// LoadChainState merges a state blob produced by DumpChainState into the chain
+func LoadChainState(ctx context.Context, backend ChainBackend, rpcURL string, state string) error {
+	client, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	if err := backend.LoadState(ctx, client, state); err != nil {
+		return fmt.Errorf("%s on %s: %w", backend.Name(), rpcURL, err)
+	}
+	return nil
+}
+
+// WriteSnapshotMetadata writes the snapshot metadata into its snapshot dir
+func WriteSnapshotMetadata(meta *SnapshotMetadata) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(SnapshotPath(meta.Name), SnapshotMetadataFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot metadata: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshotMetadata loads the metadata of the named snapshot
+func ReadSnapshotMetadata(name string) (*SnapshotMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(SnapshotPath(name), SnapshotMetadataFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("snapshot %q not found in %s", name, SnapshotsDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot metadata: %w", err)
+	}
+
+	var meta SnapshotMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot metadata: %w", err)
+	}
+	return &meta, nil
+}
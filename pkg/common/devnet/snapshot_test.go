@@ -0,0 +1,76 @@
+package devnet
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAnvil implements the anvil_* state methods over JSON-RPC
+type fakeAnvil struct {
+	state string
+}
+
+func (f *fakeAnvil) DumpState() string { return f.state }
+
+func (f *fakeAnvil) LoadState(state string) bool {
+	f.state = state
+	return true
+}
+
+func TestValidateSnapshotName(t *testing.T) {
+	for _, name := range []string{"post-setup", "v1.2", "my_snapshot"} {
+		assert.NoError(t, ValidateSnapshotName(name), name)
+	}
+	for _, name := range []string{"", "../escape", "with space", "-leading"} {
+		assert.Error(t, ValidateSnapshotName(name), name)
+	}
+}
+
+func TestDumpAndLoadChainState(t *testing.T) {
+	anvil := &fakeAnvil{state: "0x1f8b0800"}
+	url := testutils.ServeRPC(t, map[string]any{"anvil": anvil})
+
+	state, err := DumpChainState(context.Background(), AnvilBackend{}, url)
+	require.NoError(t, err)
+	assert.Equal(t, "0x1f8b0800", state)
+
+	require.NoError(t, LoadChainState(context.Background(), AnvilBackend{}, url, "0xabcdef\n"))
+	assert.Equal(t, "0xabcdef", anvil.state)
+
+	_, err = DumpChainState(context.Background(), HardhatBackend{}, url)
+	assert.ErrorIs(t, err, ErrUnsupportedByBackend)
+}
+
+func TestSnapshotMetadataRoundTrip(t *testing.T) {
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(origDir) }()
+
+	_, err = ReadSnapshotMetadata("missing")
+	assert.Error(t, err)
+
+	meta := &SnapshotMetadata{
+		Name:      "post-setup",
+		Context:   DEVNET_CONTEXT,
+		Project:   "my-avs",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Chains: map[string]SnapshotChain{
+			"l1": {ChainID: 31337, BlockNumber: 100, StateFile: SnapshotChainStateFile("l1")},
+		},
+	}
+	require.NoError(t, os.MkdirAll(SnapshotPath(meta.Name), 0755))
+	require.NoError(t, WriteSnapshotMetadata(meta))
+
+	loaded, err := ReadSnapshotMetadata("post-setup")
+	require.NoError(t, err)
+	assert.Equal(t, meta.Project, loaded.Project)
+	assert.True(t, meta.CreatedAt.Equal(loaded.CreatedAt))
+	assert.Equal(t, meta.Chains, loaded.Chains)
+}
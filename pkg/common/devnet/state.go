@@ -0,0 +1,171 @@
+package devnet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+)
+
+// StateDir is the project relative directory holding per-devnet state files
+var StateDir = filepath.Join(".devkit", "devnet")
+
+// State records the progress of a devnet so that an interrupted `devnet start` can be resumed, along with the
+// namespace and ports it runs on so that other commands can find it
+type State struct {
+	Context string `json:"context"`
+	Project string `json:"project"`
+	// Namespace names the devnet's containers, see Namespace
+	Namespace string `json:"namespace,omitempty"`
+	// AutoPorts is set when the ports were allocated by `devnet start --auto-ports`
+	AutoPorts bool `json:"auto_ports,omitempty"`
+	L1Port    int  `json:"l1_port"`
+	L2Port    int  `json:"l2_port"`
+	// L2Ports holds the ports of the L2s beyond the primary l2, keyed by chain name
+	L2Ports        map[string]int `json:"l2_ports,omitempty"`
+	NoFork         bool           `json:"no_fork,omitempty"`
+	CompletedSteps []string       `json:"completed_steps"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// StatePath returns the location of the state file for the given context
+func StatePath(contextName string) string {
+	return filepath.Join(StateDir, fmt.Sprintf("%s.json", contextName))
+}
+
+// LoadState reads the state file for the given context, returning an empty state if none has been written yet
+func LoadState(contextName string) (*State, error) {
+	data, err := os.ReadFile(StatePath(contextName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{Context: contextName}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read devnet state: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse devnet state %s: %w", StatePath(contextName), err)
+	}
+	if state.Context == "" {
+		state.Context = contextName
+	}
+	return &state, nil
+}
+
+// ErrNoState is returned by ResumeState when no devnet state has been recorded for the context
+var ErrNoState = errors.New("no devnet state recorded")
+
+// ResumeState reads the state file of an interrupted `devnet start` for the given context. Unlike LoadState it errors
+// with ErrNoState when none has been written, since there is nothing to resume.
+func ResumeState(contextName string) (*State, error) {
+	if _, err := os.Stat(StatePath(contextName)); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for context '%s' at %s", ErrNoState, contextName, StatePath(contextName))
+	}
+	return LoadState(contextName)
+}
+
+// SaveState writes the state file for the state's context
+func SaveState(state *State) error {
+	if state.Context == "" {
+		return fmt.Errorf("devnet state is missing a context name")
+	}
+	state.UpdatedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal devnet state: %w", err)
+	}
+	if err := os.MkdirAll(StateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create devnet state dir: %w", err)
+	}
+	if err := os.WriteFile(StatePath(state.Context), data, 0644); err != nil {
+		return fmt.Errorf("failed to write devnet state: %w", err)
+	}
+	return nil
+}
+
+// RemoveState deletes the state file for the given context if present
+func RemoveState(contextName string) error {
+	if err := os.Remove(StatePath(contextName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove devnet state: %w", err)
+	}
+	return nil
+}
+
+// ChainPorts returns the recorded host port of each chain, empty if the devnet has not been started
+func (s *State) ChainPorts() map[string]int {
+	ports := map[string]int{}
+	if s.L1Port != 0 {
+		ports[common.L1] = s.L1Port
+	}
+	if s.L2Port != 0 {
+		ports[common.L2] = s.L2Port
+	}
+	for name, port := range s.L2Ports {
+		ports[name] = port
+	}
+	return ports
+}
+
+// ApplyRPCURLs points the chains at the ports recorded for the devnet, chains without a recorded port are left as configured
+func (s *State) ApplyRPCURLs(chains map[string]common.ChainConfig) {
+	for name, port := range s.ChainPorts() {
+		if chain, ok := chains[name]; ok {
+			chain.RPCURL = GetRPCURL(port)
+			chains[name] = chain
+		}
+	}
+}
+
+// ApplyRPCURLsToRawContext is ApplyRPCURLs for a context loaded with common.LoadRawContext
+func (s *State) ApplyRPCURLsToRawContext(contextJSON []byte) ([]byte, error) {
+	ports := s.ChainPorts()
+	if len(ports) == 0 {
+		return contextJSON, nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(contextJSON, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse context: %w", err)
+	}
+	ctxMap, _ := raw["context"].(map[string]interface{})
+	chains, _ := ctxMap["chains"].(map[string]interface{})
+	for name, port := range ports {
+		if chain, ok := chains[name].(map[string]interface{}); ok {
+			chain["rpc_url"] = GetRPCURL(port)
+		}
+	}
+
+	out, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal context: %w", err)
+	}
+	return out, nil
+}
+
+// IsStepComplete reports whether the named setup step has already succeeded
+func (s *State) IsStepComplete(name string) bool {
+	for _, step := range s.CompletedSteps {
+		if step == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkStepComplete records the named setup step as succeeded
+func (s *State) MarkStepComplete(name string) {
+	if !s.IsStepComplete(name) {
+		s.CompletedSteps = append(s.CompletedSteps, name)
+	}
+}
+
+// ResetSteps forgets all completed setup steps
+func (s *State) ResetSteps() {
+	s.CompletedSteps = nil
+}
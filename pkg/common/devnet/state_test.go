@@ -0,0 +1,77 @@
+package devnet
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateApplyRPCURLs(t *testing.T) {
+	state := &State{L1Port: 41001, L2Port: 41002, L2Ports: map[string]int{"l2-op": 41003}}
+	assert.Equal(t, map[string]int{"l1": 41001, "l2": 41002, "l2-op": 41003}, state.ChainPorts())
+
+	chains := map[string]common.ChainConfig{
+		common.L1: {ChainID: 31337, RPCURL: "http://localhost:8545"},
+		common.L2: {ChainID: 31338, RPCURL: "http://localhost:9545"},
+		"l2-op":   {ChainID: 31339, RPCURL: "http://localhost:9546"},
+		"l2-zk":   {ChainID: 31340, RPCURL: "http://localhost:9547"},
+	}
+	state.ApplyRPCURLs(chains)
+
+	assert.Equal(t, "http://localhost:41001", chains[common.L1].RPCURL)
+	assert.Equal(t, "http://localhost:41002", chains[common.L2].RPCURL)
+	assert.Equal(t, "http://localhost:41003", chains["l2-op"].RPCURL)
+	// Chains without a recorded port keep their configured url
+	assert.Equal(t, "http://localhost:9547", chains["l2-zk"].RPCURL)
+	assert.Equal(t, 31337, chains[common.L1].ChainID)
+}
+
+func TestStateApplyRPCURLsToRawContext(t *testing.T) {
+	contextJSON := []byte(`{"context":{"name":"devnet","chains":{"l1":{"chain_id":31337,"rpc_url":"http://localhost:8545"},"l2":{"chain_id":31338,"rpc_url":"http://localhost:9545"}}}}`)
+
+	// Nothing recorded, the context is returned untouched
+	out, err := (&State{}).ApplyRPCURLsToRawContext(contextJSON)
+	require.NoError(t, err)
+	assert.Equal(t, contextJSON, out)
+
+	out, err = (&State{L1Port: 41001, L2Port: 41002}).ApplyRPCURLsToRawContext(contextJSON)
+	require.NoError(t, err)
+
+	var parsed struct {
+		Context struct {
+			Name   string `json:"name"`
+			Chains map[string]struct {
+				ChainID int    `json:"chain_id"`
+				RPCURL  string `json:"rpc_url"`
+			} `json:"chains"`
+		} `json:"context"`
+	}
+	require.NoError(t, json.Unmarshal(out, &parsed))
+	assert.Equal(t, "devnet", parsed.Context.Name)
+	assert.Equal(t, "http://localhost:41001", parsed.Context.Chains["l1"].RPCURL)
+	assert.Equal(t, "http://localhost:41002", parsed.Context.Chains["l2"].RPCURL)
+	assert.Equal(t, 31338, parsed.Context.Chains["l2"].ChainID)
+}
+
+func TestResumeState(t *testing.T) {
+	prev := StateDir
+	StateDir = filepath.Join(t.TempDir(), "devnet")
+	defer func() { StateDir = prev }()
+
+	// Nothing to resume before a state file is written, while LoadState starts from an empty state
+	_, err := ResumeState("devnet")
+	require.ErrorIs(t, err, ErrNoState)
+	state, err := LoadState("devnet")
+	require.NoError(t, err)
+	assert.Empty(t, state.CompletedSteps)
+
+	require.NoError(t, SaveState(&State{Context: "devnet", L1Port: 41001, CompletedSteps: []string{"fund"}}))
+	state, err = ResumeState("devnet")
+	require.NoError(t, err)
+	assert.Equal(t, 41001, state.L1Port)
+	assert.Equal(t, []string{"fund"}, state.CompletedSteps)
+}
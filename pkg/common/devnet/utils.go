@@ -13,8 +13,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Layr-Labs/devkit-cli/docker/anvil"
 	"github.com/Layr-Labs/devkit-cli/pkg/common"
 	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli/v2"
 )
@@ -31,6 +33,28 @@ func IsPortAvailable(port int) bool {
 	return false
 }
 
+// FreePorts asks the OS for n distinct free TCP ports on localhost. The listeners are held until every port is
+// picked so the same port is not handed out twice.
+func FreePorts(n int) ([]int, error) {
+	ports := make([]int, 0, n)
+	listeners := make([]net.Listener, 0, n)
+	defer func() {
+		for _, l := range listeners {
+			_ = l.Close()
+		}
+	}()
+
+	for len(ports) < n {
+		l, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate a free port: %w", err)
+		}
+		listeners = append(listeners, l)
+		ports = append(ports, l.Addr().(*net.TCPAddr).Port)
+	}
+	return ports, nil
+}
+
 // / Stops the container and removes it
 func StopAndRemoveContainer(ctx *cli.Context, containerName string) {
 	logger := common.LoggerFromContext(ctx.Context)
@@ -47,6 +71,25 @@ func StopAndRemoveContainer(ctx *cli.Context, containerName string) {
 	}
 }
 
+// ComposeDown takes down the devnet compose project of namespace, removing its containers, network and the named
+// volumes of its services. Returns false when it could not be taken down, e.g. for devnets started before their
+// compose file was kept, leaving the containers to StopAndRemoveContainer.
+func ComposeDown(ctx *cli.Context, namespace string) bool {
+	logger := common.LoggerFromContext(ctx.Context)
+
+	composePath := assets.GetDockerComposePath(namespace)
+	if _, err := os.Stat(composePath); err != nil {
+		return false
+	}
+	output, err := exec.CommandContext(ctx.Context, "docker", "compose", "-p", namespace, "-f", composePath, "down", "-v", "--remove-orphans").CombinedOutput()
+	if err != nil {
+		logger.Error("⚠️  Failed to take down devnet %s: %v\n%s", namespace, err, strings.TrimSpace(string(output)))
+		return false
+	}
+	logger.Info("✅ Removed devnet %s with its network and volumes", namespace)
+	return true
+}
+
 // GetDockerPsDevnetArgs returns the arguments needed to list all running
 // devkit devnet Docker containers along with their exposed ports.
 // It filters containers by name prefix ("devkit-devnet") and formats
@@ -219,86 +262,171 @@ func GetL2BlockByNumber(ctx *cli.Context, l2RpcUrl string, blockNumber uint64, l
 	return strconv.FormatUint(timestampInt, 10), nil
 }
 
-func AdvanceBlocks(ctx *cli.Context, l1RpcUrl string, numBlocks uint64) error {
-	// Connect to provided client
-	rpcClient, err := rpc.Dial(l1RpcUrl)
-	if err != nil {
-		return fmt.Errorf("failed to connect to RPC: %w", err)
-	}
-	defer rpcClient.Close()
+// TimeClient is a connection to one devnet chain used by the time travel helpers
+type TimeClient struct {
+	Name    string
+	Client  *rpc.Client
+	Backend ChainBackend
+}
+
+// ChainTime is the head block and timestamp of a devnet chain
+type ChainTime struct {
+	Name      string `json:"name"`
+	Block     uint64 `json:"block"`
+	Timestamp uint64 `json:"timestamp"`
+}
 
-	// Advance numBlocks blocks on client
-	err = rpcClient.Call(nil, "anvil_mine", numBlocks)
+// DialTimeClient connects to the devnet chain served at rpcURL
+func DialTimeClient(ctx context.Context, name string, backend ChainBackend, rpcURL string) (TimeClient, error) {
+	client, err := rpc.DialContext(ctx, rpcURL)
 	if err != nil {
-		return fmt.Errorf("failed to advance blocks: %w", err)
+		return TimeClient{}, fmt.Errorf("failed to connect to %s: %w", name, err)
 	}
+	return TimeClient{Name: name, Client: client, Backend: backend}, nil
+}
 
+// AdvanceBlocks mines numBlocks blocks on the chain
+func AdvanceBlocks(ctx context.Context, chain TimeClient, numBlocks uint64) error {
+	if err := chain.Backend.Mine(ctx, chain.Client, numBlocks); err != nil {
+		return fmt.Errorf("failed to mine on %s: %w", chain.Name, err)
+	}
 	return nil
 }
 
-func AdvanceBlocksToTS(client *rpc.Client, name string, fromTS, toTS uint64) error {
-	// Set number of blocks to move each iteration
-	const blocksPerBatch = 1
+// AdvanceBlocksToTS mines a single block stamped with toTS, which must be after the chain's current head
+func AdvanceBlocksToTS(ctx context.Context, chain TimeClient, toTS uint64) error {
+	head, err := GetTimestamp(ctx, chain)
+	if err != nil {
+		return err
+	}
+	if toTS <= head.Timestamp {
+		return fmt.Errorf("cannot warp %s to %d, its head is already at %d", chain.Name, toTS, head.Timestamp)
+	}
+	if err := chain.Backend.SetNextBlockTimestamp(ctx, chain.Client, toTS); err != nil {
+		return fmt.Errorf("failed to set next %s block timestamp: %w", chain.Name, err)
+	}
+	return AdvanceBlocks(ctx, chain, 1)
+}
 
-	// While timestamps are out of sync mine blocksPerBatch at a time
-	for fromTS < toTS {
-		if err := client.Call(nil, "anvil_mine", blocksPerBatch); err != nil {
-			return fmt.Errorf("failed to mine on %s: %w", name, err)
+// RestoreChainHead mines the chain forward to block, stamping the last block with timestamp, so a chain loaded from a
+// state dump resumes at the head and clock it was dumped at. A chain already past either is only moved forward.
+func RestoreChainHead(ctx context.Context, chain TimeClient, block, timestamp uint64) error {
+	head, err := GetTimestamp(ctx, chain)
+	if err != nil {
+		return err
+	}
+	if head.Block+1 < block {
+		if err := AdvanceBlocks(ctx, chain, block-head.Block-1); err != nil {
+			return err
 		}
-		newTS, err := GetTimestamp(client, name)
-		if err != nil {
+		if head, err = GetTimestamp(ctx, chain); err != nil {
 			return err
 		}
-		fromTS = newTS
+	}
+	switch {
+	case head.Timestamp < timestamp:
+		return AdvanceBlocksToTS(ctx, chain, timestamp)
+	case head.Block < block:
+		return AdvanceBlocks(ctx, chain, 1)
 	}
 	return nil
 }
 
-func GetTimestamp(client *rpc.Client, name string) (uint64, error) {
-	// Collect the block height for provided client
-	var block map[string]interface{}
-	if err := client.Call(&block, "eth_getBlockByNumber", "latest", false); err != nil {
-		return 0, fmt.Errorf("failed to get latest %s block: %w", name, err)
+// GetTimestamp returns the chain's latest block number and timestamp
+func GetTimestamp(ctx context.Context, chain TimeClient) (ChainTime, error) {
+	var head struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
 	}
-	tsHex, ok := block["timestamp"].(string)
-	if !ok {
-		return 0, fmt.Errorf("invalid timestamp format for %s", name)
+	if err := chain.Client.CallContext(ctx, &head, "eth_getBlockByNumber", "latest", false); err != nil {
+		return ChainTime{}, fmt.Errorf("failed to get latest %s block: %w", chain.Name, err)
 	}
-	return strconv.ParseUint(tsHex[2:], 16, 64)
+	return ChainTime{Name: chain.Name, Block: uint64(head.Number), Timestamp: uint64(head.Timestamp)}, nil
 }
 
-func SyncL1L2Timestamps(ctx *cli.Context, l1RpcUrl string, l2RpcUrl string) error {
-	// Connect to l1
-	l1Client, err := rpc.Dial(l1RpcUrl)
-	if err != nil {
-		return fmt.Errorf("failed to connect to L1: %w", err)
+// SyncL1L2Timestamps warps every chain behind the most advanced one to its timestamp, so the L1 and all L2s share a clock
+func SyncL1L2Timestamps(ctx context.Context, chains []TimeClient) error {
+	heads := make([]ChainTime, len(chains))
+	var latest uint64
+	for i, chain := range chains {
+		head, err := GetTimestamp(ctx, chain)
+		if err != nil {
+			return err
+		}
+		heads[i] = head
+		latest = max(latest, head.Timestamp)
 	}
-	defer l1Client.Close()
 
-	// Connect to l2
-	l2Client, err := rpc.Dial(l2RpcUrl)
-	if err != nil {
-		return fmt.Errorf("failed to connect to L2: %w", err)
+	// Advance the chains behind until we reach sync
+	for i, chain := range chains {
+		if heads[i].Timestamp < latest {
+			if err := AdvanceBlocksToTS(ctx, chain, latest); err != nil {
+				return err
+			}
+		}
 	}
-	defer l2Client.Close()
+	return nil
+}
 
-	// Get l1 and l2 current timestamps
-	l1TS, err := GetTimestamp(l1Client, "L1")
+// IsContainerRunning reports whether a container with exactly the given name is running
+func IsContainerRunning(ctx context.Context, containerName string) (bool, error) {
+	output, err := exec.CommandContext(ctx, "docker", "ps", "--filter", fmt.Sprintf("name=^%s$", containerName), "--format", "{{.Names}}").Output()
 	if err != nil {
-		return err
+		return false, fmt.Errorf("failed to inspect container %s: %w", containerName, err)
 	}
-	l2TS, err := GetTimestamp(l2Client, "L2")
+	return strings.TrimSpace(string(output)) == containerName, nil
+}
+
+// Namespace returns the name a project's devnet for the given context runs under, so that several projects,
+// and several contexts of one project, can run side by side
+func Namespace(projectName, contextName string) string {
+	if contextName == "" {
+		return projectName
+	}
+	return fmt.Sprintf("%s-%s", projectName, contextName)
+}
+
+// ChainContainerName returns the name of the container running the given chain in a devnet namespace
+func ChainContainerName(chainName, namespace string) string {
+	return fmt.Sprintf("devkit-devnet-%s-%s", chainName, namespace)
+}
+
+// ProjectContainerNames returns the devnet containers (running or stopped) labelled with the given project,
+// restricted to the given context unless contextName is empty
+func ProjectContainerNames(ctx context.Context, projectName, contextName string) ([]string, error) {
+	args := []string{"ps", "-a", "--filter", fmt.Sprintf("label=%s=%s", ProjectLabel, projectName)}
+	if contextName != "" {
+		args = append(args, "--filter", fmt.Sprintf("label=%s=%s", ContextLabel, contextName))
+	}
+	output, err := exec.CommandContext(ctx, "docker", append(args, "--format", "{{.Names}}")...).Output()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to list containers for project %s: %w", projectName, err)
 	}
+	var names []string
+	for _, name := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
 
-	// Advance one or the other until we reach sync
-	if l1TS > l2TS {
-		return AdvanceBlocksToTS(l2Client, "L2", l2TS, l1TS)
-	} else if l2TS > l1TS {
-		return AdvanceBlocksToTS(l1Client, "L1", l1TS, l2TS)
+// ContainerProject returns the project and context a devnet container was started for, read from its labels
+func ContainerProject(ctx context.Context, containerName string) (projectName, contextName string, err error) {
+	format := `{{ index .Config.Labels "` + ProjectLabel + `" }}/{{ index .Config.Labels "` + ContextLabel + `" }}`
+	output, err := exec.CommandContext(ctx, "docker", "inspect", "--format", format, containerName).Output()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to inspect container %s: %w", containerName, err)
 	}
+	projectName, contextName, _ = strings.Cut(strings.TrimSpace(string(output)), "/")
+	return projectName, contextName, nil
+}
 
-	// Already in sync
-	return nil
+// ContainerState returns the docker state of a container (running, exited, ...), or "" if it does not exist
+func ContainerState(ctx context.Context, containerName string) (string, error) {
+	output, err := exec.CommandContext(ctx, "docker", "ps", "-a", "--filter", fmt.Sprintf("name=^%s$", containerName), "--format", "{{.State}}").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container %s: %w", containerName, err)
+	}
+	return strings.TrimSpace(string(output)), nil
 }
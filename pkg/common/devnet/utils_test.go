@@ -1,12 +1,16 @@
 package devnet
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
+	"sync"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestGetDockerHost tests the GetDockerHost function for different platforms and environment variables
@@ -539,3 +543,119 @@ func TestNetworkingRegression(t *testing.T) {
 	})
 
 }
+
+func TestFreePorts(t *testing.T) {
+	ports, err := FreePorts(3)
+	assert.NoError(t, err)
+	assert.Len(t, ports, 3)
+
+	seen := map[int]bool{}
+	for _, port := range ports {
+		assert.False(t, seen[port], "port %d allocated twice", port)
+		seen[port] = true
+		assert.True(t, IsPortAvailable(port))
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	assert.Equal(t, "my-avs-devnet", Namespace("my-avs", "devnet"))
+	assert.Equal(t, "my-avs", Namespace("my-avs", ""))
+	assert.Equal(t, "devkit-devnet-l2-my-avs-devnet", ChainContainerName("l2", Namespace("my-avs", "devnet")))
+}
+
+// fakeClock implements the anvil time travel methods, each mined block advancing the clock by blockTime
+type fakeClock struct {
+	mu        sync.Mutex
+	block     uint64
+	timestamp uint64
+	blockTime uint64
+	next      uint64
+}
+
+type fakeClockEth struct{ *fakeClock }
+
+func (f fakeClockEth) GetBlockByNumber(tag string, full bool) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return map[string]interface{}{"number": hexutil.Uint64(f.block), "timestamp": hexutil.Uint64(f.timestamp)}
+}
+
+type fakeClockEvm struct{ *fakeClock }
+
+func (f fakeClockEvm) SetNextBlockTimestamp(ts uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.next = ts
+}
+
+type fakeClockMiner struct{ *fakeClock }
+
+func (f fakeClockMiner) Mine(numBlocks uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := uint64(0); i < numBlocks; i++ {
+		f.block++
+		if f.next != 0 {
+			f.timestamp, f.next = f.next, 0
+		} else {
+			f.timestamp += f.blockTime
+		}
+	}
+}
+
+func dialFakeClock(t *testing.T, name string, chain *fakeClock) TimeClient {
+	client := dialFakeChain(t, map[string]any{"eth": fakeClockEth{chain}, "evm": fakeClockEvm{chain}, "anvil": fakeClockMiner{chain}})
+	return TimeClient{Name: name, Client: client, Backend: AnvilBackend{}}
+}
+
+func TestAdvanceBlocks(t *testing.T) {
+	chain := &fakeClock{block: 10, timestamp: 1000, blockTime: 2}
+	client := dialFakeClock(t, "l1", chain)
+
+	require.NoError(t, AdvanceBlocks(context.Background(), client, 5))
+	head, err := GetTimestamp(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, ChainTime{Name: "l1", Block: 15, Timestamp: 1010}, head)
+}
+
+func TestAdvanceBlocksToTS(t *testing.T) {
+	chain := &fakeClock{block: 10, timestamp: 1000, blockTime: 2}
+	client := dialFakeClock(t, "l1", chain)
+
+	require.NoError(t, AdvanceBlocksToTS(context.Background(), client, 5000))
+	assert.Equal(t, uint64(11), chain.block)
+	assert.Equal(t, uint64(5000), chain.timestamp)
+
+	err := AdvanceBlocksToTS(context.Background(), client, 4000)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot warp l1 to 4000")
+}
+
+func TestSyncL1L2Timestamps(t *testing.T) {
+	l1 := &fakeClock{block: 10, timestamp: 1000, blockTime: 12}
+	l2 := &fakeClock{block: 50, timestamp: 1030, blockTime: 2}
+	l2Op := &fakeClock{block: 70, timestamp: 1030, blockTime: 2}
+
+	clients := []TimeClient{dialFakeClock(t, "l1", l1), dialFakeClock(t, "l2", l2), dialFakeClock(t, "l2-op", l2Op)}
+	require.NoError(t, SyncL1L2Timestamps(context.Background(), clients))
+
+	// Only the chain behind is warped
+	assert.Equal(t, uint64(1030), l1.timestamp)
+	assert.Equal(t, uint64(11), l1.block)
+	assert.Equal(t, uint64(50), l2.block)
+	assert.Equal(t, uint64(70), l2Op.block)
+}
+
+func TestRestoreChainHead(t *testing.T) {
+	chain := &fakeClock{block: 0, timestamp: 100, blockTime: 1}
+	client := dialFakeClock(t, "l1", chain)
+
+	require.NoError(t, RestoreChainHead(context.Background(), client, 50, 5000))
+	assert.Equal(t, uint64(50), chain.block)
+	assert.Equal(t, uint64(5000), chain.timestamp)
+
+	// A chain already at the head is left alone
+	require.NoError(t, RestoreChainHead(context.Background(), client, 50, 5000))
+	assert.Equal(t, uint64(50), chain.block)
+	assert.Equal(t, uint64(5000), chain.timestamp)
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"fmt"
 	"os"
+	"sort"
 )
 
 func GetForkUrlDefault(contextName string, cfg *ConfigWithContextConfig, chainName string) (string, error) {
@@ -23,12 +24,30 @@ func GetForkUrlDefault(contextName string, cfg *ConfigWithContextConfig, chainNa
 	if !found {
 		return "", fmt.Errorf("failed to get chainConfig for chainName : %s", chainName)
 	}
-	if chainConfig.Fork.Url == "" {
+	if chainConfig.Fork == nil || chainConfig.Fork.Url == "" {
 		return "", fmt.Errorf("fork-url not set for %s; set fork-url in ./config/context/%s.yaml or .env and consult README for guidance", chainName, contextName)
 	}
 	return chainConfig.Fork.Url, nil
 }
 
+// L2ChainNames returns the names of every L2 in chains (all chains other than l1), with the primary l2 first
+// and the remaining chains in name order
+func L2ChainNames(chains map[string]ChainConfig) []string {
+	names := make([]string, 0, len(chains))
+	for name := range chains {
+		if name != L1 {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == L2 || names[j] == L2 {
+			return names[i] == L2
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
 // GetEigenLayerAddresses returns EigenLayer L1 addresses from the context config
 // Falls back to constants if not found in context
 func GetEigenLayerAddresses(contextName string, cfg *ConfigWithContextConfig) (allocationManager, delegationManager, strategyManager, keyRegistrar, crossChainRegistry, bn254TableCalculator, ecdsaTableCalculator, releaseManager string) {
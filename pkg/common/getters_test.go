@@ -0,0 +1,19 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestL2ChainNames(t *testing.T) {
+	chains := map[string]ChainConfig{
+		"l2-op":   {ChainID: 31339},
+		L1:        {ChainID: 31337},
+		"l2-arb":  {ChainID: 31340},
+		L2:        {ChainID: 31338},
+		"l2-base": {ChainID: 31341},
+	}
+	assert.Equal(t, []string{L2, "l2-arb", "l2-base", "l2-op"}, L2ChainNames(chains))
+	assert.Empty(t, L2ChainNames(map[string]ChainConfig{L1: {}}))
+}
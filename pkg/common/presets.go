@@ -0,0 +1,348 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gopkg.in/yaml.v3"
+)
+
+// ChainPreset describes a public chain the devnet can fork, selected with `devnet start --fork` or `fork.preset`
+type ChainPreset struct {
+	Name    string
+	ChainID uint64
+	// Settlement is the preset of the L1 an L2 settles on, empty for L1s
+	Settlement string
+	// L2 is the preset paired with an L1 when only the L1 is selected, empty when EigenLayer has no L2 for it
+	L2 string
+	// ForkBlock is the block forked by default, 0 forks the latest block
+	ForkBlock int
+	// EigenLayerL1 and EigenLayerL2 hold the known EigenLayer deployments, empty fields are left to the context
+	EigenLayerL1 *EigenLayerL1Config
+	EigenLayerL2 *EigenLayerL2Config
+}
+
+// IsL2 reports whether the preset is an L2
+func (p ChainPreset) IsL2() bool {
+	return p.Settlement != ""
+}
+
+// ChainPresets is the catalog of chains known to devkit keyed by preset name
+var ChainPresets = map[string]ChainPreset{
+	"sepolia": {
+		Name:      "sepolia",
+		ChainID:   11155111,
+		L2:        "base-sepolia",
+		ForkBlock: 8836193,
+		EigenLayerL1: &EigenLayerL1Config{
+			AllocationManager:    ALLOCATION_MANAGER_ADDRESS,
+			DelegationManager:    DELEGATION_MANAGER_ADDRESS,
+			StrategyManager:      STRATEGY_MANAGER_ADDRESS,
+			BN254TableCalculator: BN254_TABLE_CALCULATOR_ADDRESS,
+			ECDSATableCalculator: ECDSA_TABLE_CALCULATOR_ADDRESS,
+			CrossChainRegistry:   CROSS_CHAIN_REGISTRY_ADDRESS,
+			KeyRegistrar:         KEY_REGISTRAR_ADDRESS,
+			ReleaseManager:       RELEASE_MANAGER_ADDRESS,
+			OperatorTableUpdater: "0xB02A15c6Bd0882b35e9936A9579f35FB26E11476",
+			TaskMailbox:          "0xB99CC53e8db7018f557606C2a5B066527bF96b26",
+		},
+	},
+	"base-sepolia": {
+		Name:       "base-sepolia",
+		ChainID:    84532,
+		Settlement: "sepolia",
+		ForkBlock:  28820370,
+		EigenLayerL2: &EigenLayerL2Config{
+			BN254CertificateVerifier: "0xff58A373c18268F483C1F5cA03Cf885c0C43373a",
+			ECDSACertificateVerifier: "0xb3Cd1A457dEa9A9A6F6406c6419B1c326670A96F",
+			OperatorTableUpdater:     "0xB02A15c6Bd0882b35e9936A9579f35FB26E11476",
+			TaskMailbox:              "0xB99CC53e8db7018f557606C2a5B066527bF96b26",
+		},
+	},
+	"op-sepolia": {
+		Name:       "op-sepolia",
+		ChainID:    11155420,
+		Settlement: "sepolia",
+	},
+	"holesky": {
+		Name:    "holesky",
+		ChainID: 17000,
+		EigenLayerL1: &EigenLayerL1Config{
+			AllocationManager: "0x78469728304326CBc65f8f95FA756B0B73164462",
+			DelegationManager: "0xA44151489861Fe9e3055d95adC98FbD462B948e7",
+			StrategyManager:   "0xdfB5f6CE42aAA7830E94ECFCcAd411beF4d4D5b6",
+		},
+	},
+	"mainnet": {
+		Name:    "mainnet",
+		ChainID: 1,
+		L2:      "base",
+		EigenLayerL1: &EigenLayerL1Config{
+			AllocationManager: "0x948a420b8CC1d6BFd0B6087C2E7c344a2CD0bc39",
+			DelegationManager: "0x39053D51B77DC0d36036Fc1fCc8Cb819df8Ef37A",
+			StrategyManager:   "0x858646372CC42E1A627fcE94aa7A7033e7CF075A",
+		},
+	},
+	"base": {
+		Name:       "base",
+		ChainID:    8453,
+		Settlement: "mainnet",
+	},
+	"optimism": {
+		Name:       "optimism",
+		ChainID:    10,
+		Settlement: "mainnet",
+	},
+}
+
+// MissingEigenLayerAddresses returns the context.eigenlayer keys of the preset's layer which the catalog has no
+// address for
+func (p ChainPreset) MissingEigenLayerAddresses() []string {
+	var fields [][2]string
+	if p.IsL2() {
+		l2 := EigenLayerL2Config{}
+		if p.EigenLayerL2 != nil {
+			l2 = *p.EigenLayerL2
+		}
+		fields = l2.NamedAddresses()
+	} else {
+		l1 := EigenLayerL1Config{}
+		if p.EigenLayerL1 != nil {
+			l1 = *p.EigenLayerL1
+		}
+		fields = l1.NamedAddresses()
+	}
+
+	var missing []string
+	for _, field := range fields {
+		if field[1] == "" {
+			missing = append(missing, field[0])
+		}
+	}
+	return missing
+}
+
+// chainPresetAliases maps alternative spellings accepted by --fork onto preset names
+var chainPresetAliases = map[string]string{
+	"ethereum":     "mainnet",
+	"op":           "optimism",
+	"op-mainnet":   "optimism",
+	"base-mainnet": "base",
+	"basesepolia":  "base-sepolia",
+	"opsepolia":    "op-sepolia",
+}
+
+// ChainPresetNames returns the names of every preset in the catalog, sorted
+func ChainPresetNames() []string {
+	names := make([]string, 0, len(ChainPresets))
+	for name := range ChainPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetChainPreset looks up a preset by name or alias, ignoring case and spaces ("Base Sepolia" is base-sepolia)
+func GetChainPreset(name string) (ChainPreset, error) {
+	key := strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(name, "_", " "))), "-")
+	if alias, ok := chainPresetAliases[key]; ok {
+		key = alias
+	}
+	preset, ok := ChainPresets[key]
+	if !ok {
+		return ChainPreset{}, fmt.Errorf("unknown chain preset %q, expected one of %s", name, strings.Join(ChainPresetNames(), ", "))
+	}
+	return preset, nil
+}
+
+// ResolveForkPresets returns the presets the l1 and l2 fork for a `--fork` selection. Selecting an L2 forks the L1 it
+// settles on, selecting an L1 forks its paired L2 (l2 is nil when it has none and the context's l2 is kept).
+func ResolveForkPresets(name string) (l1 ChainPreset, l2 *ChainPreset, err error) {
+	preset, err := GetChainPreset(name)
+	if err != nil {
+		return ChainPreset{}, nil, err
+	}
+
+	if preset.IsL2() {
+		l1, err = GetChainPreset(preset.Settlement)
+		if err != nil {
+			return ChainPreset{}, nil, err
+		}
+		return l1, &preset, nil
+	}
+
+	if preset.L2 != "" {
+		paired, err := GetChainPreset(preset.L2)
+		if err != nil {
+			return ChainPreset{}, nil, err
+		}
+		return preset, &paired, nil
+	}
+	return preset, nil, nil
+}
+
+// ChainForkPreset returns the preset a context chain forks, named in its fork.preset. ok is false for chains which do
+// not name a preset.
+func ChainForkPreset(chainName string, chain ChainConfig) (preset ChainPreset, ok bool, err error) {
+	if chain.Fork == nil || chain.Fork.Preset == "" {
+		return ChainPreset{}, false, nil
+	}
+
+	preset, err = GetChainPreset(chain.Fork.Preset)
+	if err != nil {
+		return ChainPreset{}, false, fmt.Errorf("chain %s: %w", chainName, err)
+	}
+	return preset, true, nil
+}
+
+// ForkedChainIDs returns the chain ids of the public chains forked by the context's chains, which are registered in
+// the forked CrossChainRegistry but not served by the devnet. A chain naming a preset forks the preset's chain, any
+// other chain forks the chain its fork provider reports. Chains without a fork url are not forked.
+func ForkedChainIDs(ctx context.Context, contextName string, cfg *ConfigWithContextConfig) ([]uint64, error) {
+	envCtx, ok := cfg.Context[contextName]
+	if !ok {
+		return nil, fmt.Errorf("context '%s' not found in configuration", contextName)
+	}
+
+	var ids []uint64
+	for _, name := range append([]string{L1}, L2ChainNames(envCtx.Chains)...) {
+		preset, ok, err := ChainForkPreset(name, envCtx.Chains[name])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ids = append(ids, preset.ChainID)
+			continue
+		}
+
+		forkUrl, err := GetForkUrlDefault(contextName, cfg, name)
+		if err != nil {
+			continue
+		}
+		chainID, err := forkProviderChainID(ctx, forkUrl)
+		if err != nil {
+			return nil, fmt.Errorf("chain %s: %w", name, err)
+		}
+		ids = append(ids, chainID)
+	}
+	return ids, nil
+}
+
+// forkProviderChainID returns the chain id of the chain served at forkUrl
+func forkProviderChainID(ctx context.Context, forkUrl string) (uint64, error) {
+	client, err := ethclient.DialContext(ctx, forkUrl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to the fork provider: %w", err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the fork provider's chain id: %w", err)
+	}
+	return chainID.Uint64(), nil
+}
+
+// ApplyForkPresets fills the fork blocks and EigenLayer addresses the context leaves unset from the presets its
+// chains name in fork.preset. Chains without a preset are left as configured.
+func ApplyForkPresets(envCtx *ChainContextConfig) error {
+	for name, chain := range envCtx.Chains {
+		if chain.Fork == nil || chain.Fork.Preset == "" {
+			continue
+		}
+		preset, err := GetChainPreset(chain.Fork.Preset)
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", name, err)
+		}
+
+		if chain.Fork.Block == 0 {
+			chain.Fork.Block = preset.ForkBlock
+		}
+
+		// The EigenLayer section holds a single L1 and L2 deployment, read from the l1 and primary l2
+		if envCtx.EigenLayer == nil {
+			envCtx.EigenLayer = &EigenLayerConfig{}
+		}
+		switch {
+		case name == L1 && preset.EigenLayerL1 != nil:
+			fillEmpty(&envCtx.EigenLayer.L1.AllocationManager, preset.EigenLayerL1.AllocationManager)
+			fillEmpty(&envCtx.EigenLayer.L1.DelegationManager, preset.EigenLayerL1.DelegationManager)
+			fillEmpty(&envCtx.EigenLayer.L1.StrategyManager, preset.EigenLayerL1.StrategyManager)
+			fillEmpty(&envCtx.EigenLayer.L1.BN254TableCalculator, preset.EigenLayerL1.BN254TableCalculator)
+			fillEmpty(&envCtx.EigenLayer.L1.ECDSATableCalculator, preset.EigenLayerL1.ECDSATableCalculator)
+			fillEmpty(&envCtx.EigenLayer.L1.CrossChainRegistry, preset.EigenLayerL1.CrossChainRegistry)
+			fillEmpty(&envCtx.EigenLayer.L1.KeyRegistrar, preset.EigenLayerL1.KeyRegistrar)
+			fillEmpty(&envCtx.EigenLayer.L1.ReleaseManager, preset.EigenLayerL1.ReleaseManager)
+			fillEmpty(&envCtx.EigenLayer.L1.OperatorTableUpdater, preset.EigenLayerL1.OperatorTableUpdater)
+			fillEmpty(&envCtx.EigenLayer.L1.TaskMailbox, preset.EigenLayerL1.TaskMailbox)
+		case name == L2 && preset.EigenLayerL2 != nil:
+			fillEmpty(&envCtx.EigenLayer.L2.BN254CertificateVerifier, preset.EigenLayerL2.BN254CertificateVerifier)
+			fillEmpty(&envCtx.EigenLayer.L2.ECDSACertificateVerifier, preset.EigenLayerL2.ECDSACertificateVerifier)
+			fillEmpty(&envCtx.EigenLayer.L2.OperatorTableUpdater, preset.EigenLayerL2.OperatorTableUpdater)
+			fillEmpty(&envCtx.EigenLayer.L2.TaskMailbox, preset.EigenLayerL2.TaskMailbox)
+		}
+	}
+	return nil
+}
+
+func fillEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+// UpdateContextWithForkPresets points the context node's l1 (and l2 when given) at the presets, writing the fork block
+// and EigenLayer addresses each preset defines. As with ApplyForkPresets, fields the preset leaves unset keep their
+// value in context and are left to --use-zeus or the user, see ChainPreset.MissingEigenLayerAddresses.
+func UpdateContextWithForkPresets(ctx *yaml.Node, l1 ChainPreset, l2 *ChainPreset) error {
+	var updates [][]string
+	// set queues a write of val at path, skipping the values a preset leaves unset
+	set := func(val string, path ...string) {
+		if val != "" {
+			updates = append(updates, append([]string{val}, path...))
+		}
+	}
+	forkBlock := func(preset ChainPreset) string {
+		if preset.ForkBlock == 0 {
+			return ""
+		}
+		return strconv.Itoa(preset.ForkBlock)
+	}
+
+	set(l1.Name, "chains", L1, "fork", "preset")
+	set(forkBlock(l1), "chains", L1, "fork", "block")
+	if l1.EigenLayerL1 != nil {
+		set(l1.EigenLayerL1.AllocationManager, "eigenlayer", "l1", "allocation_manager")
+		set(l1.EigenLayerL1.DelegationManager, "eigenlayer", "l1", "delegation_manager")
+		set(l1.EigenLayerL1.StrategyManager, "eigenlayer", "l1", "strategy_manager")
+		set(l1.EigenLayerL1.BN254TableCalculator, "eigenlayer", "l1", "bn254_table_calculator")
+		set(l1.EigenLayerL1.ECDSATableCalculator, "eigenlayer", "l1", "ecdsa_table_calculator")
+		set(l1.EigenLayerL1.CrossChainRegistry, "eigenlayer", "l1", "cross_chain_registry")
+		set(l1.EigenLayerL1.KeyRegistrar, "eigenlayer", "l1", "key_registrar")
+		set(l1.EigenLayerL1.ReleaseManager, "eigenlayer", "l1", "release_manager")
+		set(l1.EigenLayerL1.OperatorTableUpdater, "eigenlayer", "l1", "operator_table_updater")
+		set(l1.EigenLayerL1.TaskMailbox, "eigenlayer", "l1", "task_mailbox")
+	}
+
+	if l2 != nil {
+		set(l2.Name, "chains", L2, "fork", "preset")
+		set(forkBlock(*l2), "chains", L2, "fork", "block")
+		if l2.EigenLayerL2 != nil {
+			set(l2.EigenLayerL2.BN254CertificateVerifier, "eigenlayer", "l2", "bn254_certificate_verifier")
+			set(l2.EigenLayerL2.ECDSACertificateVerifier, "eigenlayer", "l2", "ecdsa_certificate_verifier")
+			set(l2.EigenLayerL2.OperatorTableUpdater, "eigenlayer", "l2", "operator_table_updater")
+			set(l2.EigenLayerL2.TaskMailbox, "eigenlayer", "l2", "task_mailbox")
+		}
+	}
+
+	for _, update := range updates {
+		if _, err := WriteToPath(ctx, update[1:], update[0]); err != nil {
+			return fmt.Errorf("failed to set %s: %w", strings.Join(update[1:], "."), err)
+		}
+	}
+	return nil
+}
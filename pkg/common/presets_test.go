@@ -0,0 +1,171 @@
+package common
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGetChainPreset(t *testing.T) {
+	for _, name := range []string{"base-sepolia", "Base Sepolia", "BASE_SEPOLIA", "basesepolia"} {
+		preset, err := GetChainPreset(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, uint64(84532), preset.ChainID, name)
+	}
+
+	preset, err := GetChainPreset("OP")
+	require.NoError(t, err)
+	assert.Equal(t, "optimism", preset.Name)
+
+	_, err = GetChainPreset("goerli")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "holesky")
+}
+
+func TestChainPresetsAreConsistent(t *testing.T) {
+	for name, preset := range ChainPresets {
+		assert.Equal(t, name, preset.Name)
+		if preset.IsL2() {
+			settlement, err := GetChainPreset(preset.Settlement)
+			require.NoError(t, err, name)
+			assert.False(t, settlement.IsL2(), name)
+		}
+		if preset.L2 != "" {
+			paired, err := GetChainPreset(preset.L2)
+			require.NoError(t, err, name)
+			assert.Equal(t, name, paired.Settlement)
+		}
+	}
+}
+
+func TestResolveForkPresets(t *testing.T) {
+	l1, l2, err := ResolveForkPresets("op-sepolia")
+	require.NoError(t, err)
+	assert.Equal(t, "sepolia", l1.Name)
+	require.NotNil(t, l2)
+	assert.Equal(t, "op-sepolia", l2.Name)
+
+	l1, l2, err = ResolveForkPresets("mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", l1.Name)
+	require.NotNil(t, l2)
+	assert.Equal(t, "base", l2.Name)
+
+	l1, l2, err = ResolveForkPresets("holesky")
+	require.NoError(t, err)
+	assert.Equal(t, "holesky", l1.Name)
+	assert.Nil(t, l2)
+}
+
+// fakeChainID serves eth_chainId with a fixed chain id
+type fakeChainID struct {
+	id uint64
+}
+
+func (f *fakeChainID) ChainId() hexutil.Uint64 {
+	return hexutil.Uint64(f.id)
+}
+
+func TestForkedChainIDs(t *testing.T) {
+	t.Setenv("L1_FORK_URL", "")
+	t.Setenv("L2_FORK_URL", "")
+	forkedIDs := func(chains map[string]ChainConfig) ([]uint64, error) {
+		return ForkedChainIDs(context.Background(), "devnet", &ConfigWithContextConfig{Context: map[string]ChainContextConfig{"devnet": {Chains: chains}}})
+	}
+
+	// Chains without a preset or a fork url are not forked
+	ids, err := forkedIDs(map[string]ChainConfig{L1: {}, L2: {Fork: &ForkConfig{}}, "l2-extra": {}})
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	ids, err = forkedIDs(map[string]ChainConfig{
+		L1:         {Fork: &ForkConfig{Preset: "mainnet"}},
+		L2:         {Fork: &ForkConfig{Preset: "base"}},
+		"l2-extra": {Fork: &ForkConfig{Preset: "optimism"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 8453, 10}, ids)
+
+	// Without a preset the chain id is read from the fork provider
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &fakeChainID{id: 1}))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	ids, err = forkedIDs(map[string]ChainConfig{
+		L1: {Fork: &ForkConfig{Url: httpServer.URL}},
+		L2: {Fork: &ForkConfig{Preset: "base"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 8453}, ids)
+
+	_, err = forkedIDs(map[string]ChainConfig{L1: {Fork: &ForkConfig{Preset: "nope"}}})
+	require.Error(t, err)
+}
+
+func TestApplyForkPresets(t *testing.T) {
+	envCtx := ChainContextConfig{
+		Chains: map[string]ChainConfig{
+			L1: {Fork: &ForkConfig{Preset: "sepolia"}},
+			L2: {Fork: &ForkConfig{Preset: "base-sepolia", Block: 123}},
+		},
+		EigenLayer: &EigenLayerConfig{L1: EigenLayerL1Config{AllocationManager: "0xcustom"}},
+	}
+	require.NoError(t, ApplyForkPresets(&envCtx))
+
+	assert.Equal(t, 8836193, envCtx.Chains[L1].Fork.Block)
+	assert.Equal(t, 123, envCtx.Chains[L2].Fork.Block)
+	assert.Equal(t, "0xcustom", envCtx.EigenLayer.L1.AllocationManager)
+	assert.Equal(t, DELEGATION_MANAGER_ADDRESS, envCtx.EigenLayer.L1.DelegationManager)
+	assert.Equal(t, "0xff58A373c18268F483C1F5cA03Cf885c0C43373a", envCtx.EigenLayer.L2.BN254CertificateVerifier)
+
+	// Chains without a preset are left as configured
+	plain := ChainContextConfig{Chains: map[string]ChainConfig{L1: {Fork: &ForkConfig{}}}}
+	require.NoError(t, ApplyForkPresets(&plain))
+	assert.Equal(t, 0, plain.Chains[L1].Fork.Block)
+	assert.Nil(t, plain.EigenLayer)
+}
+
+func TestUpdateContextWithForkPresets(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`
+chains:
+  l1:
+    chain_id: 31337
+    fork:
+      block: 8836193
+      url: ""
+  l2:
+    chain_id: 31338
+    fork:
+      block: 28820370
+      url: ""
+eigenlayer:
+  l1:
+    allocation_manager: "0x42583067658071247ec8CE0A516A58f682002d07"
+    cross_chain_registry: "0x287381B1570d9048c4B4C7EC94d21dDb8Aa1352a"
+`), &doc))
+	ctxNode := doc.Content[0]
+
+	l1, l2, err := ResolveForkPresets("base")
+	require.NoError(t, err)
+	require.NoError(t, UpdateContextWithForkPresets(ctxNode, l1, l2))
+
+	var envCtx ChainContextConfig
+	require.NoError(t, ctxNode.Decode(&envCtx))
+	assert.Equal(t, "mainnet", envCtx.Chains[L1].Fork.Preset)
+	assert.Equal(t, "base", envCtx.Chains[L2].Fork.Preset)
+	assert.Equal(t, 31338, envCtx.Chains[L2].ChainID)
+	assert.Equal(t, "0x948a420b8CC1d6BFd0B6087C2E7c344a2CD0bc39", envCtx.EigenLayer.L1.AllocationManager)
+	// Fields the presets leave unset keep their value in context
+	assert.Equal(t, 8836193, envCtx.Chains[L1].Fork.Block)
+	assert.Equal(t, "0x287381B1570d9048c4B4C7EC94d21dDb8Aa1352a", envCtx.EigenLayer.L1.CrossChainRegistry)
+	assert.Empty(t, envCtx.EigenLayer.L2.BN254CertificateVerifier)
+	assert.Contains(t, l1.MissingEigenLayerAddresses(), "cross_chain_registry")
+	assert.Empty(t, ChainPresets["sepolia"].MissingEigenLayerAddresses())
+}
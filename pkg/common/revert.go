@@ -0,0 +1,253 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+	crosschainregistry "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/CrossChainRegistry"
+	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	keyregistrar "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/KeyRegistrar"
+	releasemanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/ReleaseManager"
+)
+
+// RevertDecoder turns revert data into readable errors using the ABIs of the contracts a transaction may call
+type RevertDecoder struct {
+	contracts []revertContract
+}
+
+type revertContract struct {
+	name    string
+	address common.Address
+	abi     *abi.ABI
+}
+
+// NewRevertDecoder returns a decoder for the errors of the EigenLayer core contracts
+func NewRevertDecoder() *RevertDecoder {
+	d := &RevertDecoder{}
+	core := []struct {
+		name     string
+		metadata interface{ GetAbi() (*abi.ABI, error) }
+	}{
+		{"AllocationManager", allocationmanager.AllocationManagerMetaData},
+		{"DelegationManager", delegationmanager.DelegationManagerMetaData},
+		{"KeyRegistrar", keyregistrar.KeyRegistrarMetaData},
+		{"ReleaseManager", releasemanager.ReleaseManagerMetaData},
+		{"CrossChainRegistry", crosschainregistry.CrossChainRegistryMetaData},
+	}
+	for _, c := range core {
+		// The bindings' ABIs are generated, so they always parse
+		if parsed, err := c.metadata.GetAbi(); err == nil {
+			d.AddABI(c.name, common.Address{}, parsed)
+		}
+	}
+	return d
+}
+
+// AddABI registers the ABI of a contract, a zero address matches errors of any contract
+func (d *RevertDecoder) AddABI(name string, address common.Address, parsed *abi.ABI) {
+	d.contracts = append(d.contracts, revertContract{name: name, address: address, abi: parsed})
+}
+
+// AddArtifact registers the ABI of a forge artifact or a plain ABI JSON file
+func (d *RevertDecoder) AddArtifact(name string, address common.Address, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read ABI of %s: %w", name, err)
+	}
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if json.Unmarshal(raw, &artifact) == nil && len(artifact.ABI) > 0 {
+		raw = artifact.ABI
+	}
+	parsed, err := abi.JSON(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI of %s: %w", name, err)
+	}
+	d.AddABI(name, address, &parsed)
+	return nil
+}
+
+// SetAddress records the address of the registered contract name, so its errors win over same-named errors of others
+func (d *RevertDecoder) SetAddress(name string, address common.Address) {
+	for i := range d.contracts {
+		if d.contracts[i].name == name {
+			d.contracts[i].address = address
+		}
+	}
+}
+
+// candidates returns the contracts deployed at address first, followed by those with an unknown address
+func (d *RevertDecoder) candidates(address common.Address) []revertContract {
+	var matching, others []revertContract
+	for _, c := range d.contracts {
+		if c.address == address && address != (common.Address{}) {
+			matching = append(matching, c)
+		} else {
+			others = append(others, c)
+		}
+	}
+	return append(matching, others...)
+}
+
+// Decode describes revert data of a call to address, e.g. `AllocationManager.InvalidOperatorSet()`.
+// Returns "" for empty revert data.
+func (d *RevertDecoder) Decode(address common.Address, data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(data) >= 4 {
+		for _, c := range d.candidates(address) {
+			for _, e := range c.abi.Errors {
+				if !bytes.Equal(e.ID[:4], data[:4]) {
+					continue
+				}
+				args, err := e.Inputs.Unpack(data[4:])
+				if err != nil {
+					continue
+				}
+				return fmt.Sprintf("%s.%s(%s)", c.name, e.Name, formatABIArgs(args))
+			}
+		}
+	}
+	return fmt.Sprintf("unknown error %s", hexutil.Encode(data))
+}
+
+// methodName describes the function a call's input selects, e.g. `AllocationManager.createOperatorSets`
+func (d *RevertDecoder) methodName(address common.Address, input []byte) string {
+	if len(input) < 4 {
+		return ""
+	}
+	for _, c := range d.candidates(address) {
+		if method, err := c.abi.MethodById(input[:4]); err == nil {
+			return c.name + "." + method.Name
+		}
+	}
+	return hexutil.Encode(input[:4])
+}
+
+// DecodeCall describes a call's input with its decoded arguments, e.g. `AllocationManager.createOperatorSets(0x..., [...])`
+func (d *RevertDecoder) DecodeCall(address common.Address, input []byte) string {
+	if len(input) < 4 {
+		return "transfer"
+	}
+	for _, c := range d.candidates(address) {
+		method, err := c.abi.MethodById(input[:4])
+		if err != nil {
+			continue
+		}
+		args, err := method.Inputs.Unpack(input[4:])
+		if err != nil {
+			continue
+		}
+		return fmt.Sprintf("%s.%s(%s)", c.name, method.Name, formatABIArgs(args))
+	}
+	return fmt.Sprintf("unknown method %s", hexutil.Encode(input[:4]))
+}
+
+// DecorateError appends the decoded reason of a reverted call to err, if err carries revert data
+func (d *RevertDecoder) DecorateError(address common.Address, err error) error {
+	data := RevertData(err)
+	if len(data) == 0 {
+		return err
+	}
+	reason := d.Decode(address, data)
+	if strings.Contains(err.Error(), reason) {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, reason)
+}
+
+// RevertData extracts the revert data the node attached to a failed call, nil if there is none
+func RevertData(err error) []byte {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil
+	}
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil
+	}
+	data, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil {
+		return nil
+	}
+	return data
+}
+
+func formatABIArgs(args []interface{}) string {
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			formatted[i] = fmt.Sprintf("%q", v)
+		case []byte:
+			formatted[i] = hexutil.Encode(v)
+		case common.Address:
+			formatted[i] = v.Hex()
+		default:
+			// Fixed size byte arrays, e.g. bytes32
+			if rv := reflect.ValueOf(arg); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+				formatted[i] = fmt.Sprintf("0x%x", arg)
+			} else {
+				formatted[i] = fmt.Sprintf("%v", arg)
+			}
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// callFrame is a frame of the callTracer output of debug_traceTransaction
+type callFrame struct {
+	Type   string         `json:"type"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Input  hexutil.Bytes  `json:"input"`
+	Output hexutil.Bytes  `json:"output"`
+	Error  string         `json:"error"`
+	Calls  []callFrame    `json:"calls"`
+}
+
+// TraceTransaction returns the call trace of a mined transaction, one line per call with decoded methods and
+// errors. The node must serve debug_traceTransaction.
+func (d *RevertDecoder) TraceTransaction(ctx context.Context, client *rpc.Client, hash common.Hash) (string, error) {
+	var root callFrame
+	if err := client.CallContext(ctx, &root, "debug_traceTransaction", hash, map[string]string{"tracer": "callTracer"}); err != nil {
+		return "", fmt.Errorf("debug_traceTransaction failed: %w", err)
+	}
+	var b strings.Builder
+	d.writeCallFrame(&b, root, 0)
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func (d *RevertDecoder) writeCallFrame(b *strings.Builder, frame callFrame, depth int) {
+	fmt.Fprintf(b, "%s%s %s -> %s", strings.Repeat("  ", depth), frame.Type, frame.From.Hex(), frame.To.Hex())
+	if method := d.methodName(frame.To, frame.Input); method != "" {
+		fmt.Fprintf(b, " %s", method)
+	}
+	if frame.Error != "" {
+		fmt.Fprintf(b, ": %s", frame.Error)
+		if reason := d.Decode(frame.To, frame.Output); reason != "" {
+			fmt.Fprintf(b, ": %s", reason)
+		}
+	}
+	b.WriteString("\n")
+	for _, call := range frame.Calls {
+		d.writeCallFrame(b, call, depth+1)
+	}
+}
@@ -0,0 +1,146 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	delegationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/DelegationManager"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// selector returns the first four bytes of the keccak hash of an error or method signature
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
+
+func TestRevertDecoderDecode(t *testing.T) {
+	d := NewRevertDecoder()
+
+	assert.Equal(t, "AllocationManager.InvalidOperatorSet()", d.Decode(common.Address{}, selector("InvalidOperatorSet()")))
+	assert.Equal(t, "", d.Decode(common.Address{}, nil))
+	assert.Equal(t, "unknown error 0xdeadbeef", d.Decode(common.Address{}, hexutil.MustDecode("0xdeadbeef")))
+
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	reason, err := abi.Arguments{{Type: stringType}}.Pack("not the owner")
+	require.NoError(t, err)
+	assert.Equal(t, "not the owner", d.Decode(common.Address{}, append(selector("Error(string)"), reason...)))
+
+	// Errors shared by core contracts resolve to the contract at the called address
+	delegationManager := common.HexToAddress("0xD4A7E1Bd8015057293f0D0A557088c286942e84b")
+	d.SetAddress("DelegationManager", delegationManager)
+	assert.Equal(t, "DelegationManager.CurrentlyPaused()", d.Decode(delegationManager, selector("CurrentlyPaused()")))
+	assert.Equal(t, "AllocationManager.CurrentlyPaused()", d.Decode(common.Address{}, selector("CurrentlyPaused()")))
+}
+
+func TestRevertDecoderAddArtifact(t *testing.T) {
+	artifact := filepath.Join(t.TempDir(), "TaskMailbox.json")
+	require.NoError(t, os.WriteFile(artifact, []byte(`{"abi":[
+		{"type":"error","name":"TaskExpired","inputs":[{"name":"taskHash","type":"bytes32"},{"name":"caller","type":"address"}]}
+	],"bytecode":{"object":"0x"}}`), 0644))
+
+	d := NewRevertDecoder()
+	require.NoError(t, d.AddArtifact("TaskMailbox", common.HexToAddress("0x2001"), artifact))
+	require.Error(t, d.AddArtifact("Missing", common.Address{}, filepath.Join(t.TempDir(), "Missing.json")))
+
+	bytes32Type, err := abi.NewType("bytes32", "", nil)
+	require.NoError(t, err)
+	addressType, err := abi.NewType("address", "", nil)
+	require.NoError(t, err)
+	caller := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	args, err := abi.Arguments{{Type: bytes32Type}, {Type: addressType}}.Pack([32]byte{0xab}, caller)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"TaskMailbox.TaskExpired(0xab"+strings.Repeat("0", 62)+", 0x70997970C51812dc3A010C7d01b50e0d17dc79C8)",
+		d.Decode(common.HexToAddress("0x2001"), append(selector("TaskExpired(bytes32,address)"), args...)),
+	)
+}
+
+func TestRevertDecoderDecodeCall(t *testing.T) {
+	d := NewRevertDecoder()
+	parsed, err := delegationmanager.DelegationManagerMetaData.GetAbi()
+	require.NoError(t, err)
+	operator := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	input, err := parsed.Pack("registerAsOperator", operator, uint32(0), "test")
+	require.NoError(t, err)
+
+	assert.Equal(t, `DelegationManager.registerAsOperator(0x70997970C51812dc3A010C7d01b50e0d17dc79C8, 0, "test")`, d.DecodeCall(common.Address{}, input))
+	assert.Equal(t, "transfer", d.DecodeCall(common.Address{}, nil))
+	assert.Equal(t, "unknown method 0xdeadbeef", d.DecodeCall(common.Address{}, hexutil.MustDecode("0xdeadbeef")))
+}
+
+// revertDataError is a node error carrying revert data
+type revertDataError struct{ data string }
+
+func (e revertDataError) Error() string          { return "execution reverted" }
+func (e revertDataError) ErrorData() interface{} { return e.data }
+
+func TestRevertDecoderDecorateError(t *testing.T) {
+	d := NewRevertDecoder()
+
+	err := d.DecorateError(common.Address{}, revertDataError{data: hexutil.Encode(selector("InvalidOperatorSet()"))})
+	assert.EqualError(t, err, "execution reverted: AllocationManager.InvalidOperatorSet()")
+
+	plain := errors.New("insufficient funds")
+	assert.Equal(t, plain, d.DecorateError(common.Address{}, plain))
+}
+
+// fakeTracer serves debug_traceTransaction with a fixed call trace
+type fakeTracer struct {
+	trace callFrame
+}
+
+func (f *fakeTracer) TraceTransaction(hash common.Hash, config map[string]string) callFrame {
+	return f.trace
+}
+
+func TestRevertDecoderTraceTransaction(t *testing.T) {
+	avs := common.HexToAddress("0x0000000000000000000000000000000000001234")
+	allocationManager := common.HexToAddress("0x42583067658071247ec8CE0A516A58f682002d07")
+	tracer := &fakeTracer{trace: callFrame{
+		Type:   "CALL",
+		From:   common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		To:     avs,
+		Input:  selector("run()"),
+		Error:  "execution reverted",
+		Output: selector("InvalidOperatorSet()"),
+		Calls: []callFrame{{
+			Type:   "CALL",
+			From:   avs,
+			To:     allocationManager,
+			Input:  selector("createOperatorSets(address,(uint32,address[])[])"),
+			Error:  "execution reverted",
+			Output: selector("InvalidOperatorSet()"),
+		}},
+	}}
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("debug", tracer))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	client, err := rpc.Dial(httpServer.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	d := NewRevertDecoder()
+	d.SetAddress("AllocationManager", allocationManager)
+	trace, err := d.TraceTransaction(context.Background(), client, common.Hash{})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"CALL 0x70997970C51812dc3A010C7d01b50e0d17dc79C8 -> 0x0000000000000000000000000000000000001234 0xc0406226: execution reverted: AllocationManager.InvalidOperatorSet()\n"+
+			"  CALL 0x0000000000000000000000000000000000001234 -> 0x42583067658071247ec8CE0A516A58f682002d07 AllocationManager.createOperatorSets: execution reverted: AllocationManager.InvalidOperatorSet()",
+		trace,
+	)
+}
@@ -0,0 +1,151 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	allocationmanager "github.com/Layr-Labs/eigenlayer-contracts/pkg/bindings/AllocationManager"
+)
+
+// StateDiff compares an item of the context's setup with the chain
+type StateDiff struct {
+	Item string
+	// Want is the state the context declares, Have the state on chain
+	Want string
+	Have string
+}
+
+// InSync reports whether the chain already matches the context
+func (d StateDiff) InSync() bool {
+	return d.Want == d.Have
+}
+
+func (d StateDiff) String() string {
+	if d.InSync() {
+		return fmt.Sprintf("%s: %s", d.Item, d.Have)
+	}
+	return fmt.Sprintf("%s: %s on chain, context wants %s", d.Item, d.Have, d.Want)
+}
+
+const (
+	stateExists        = "exists"
+	stateMissing       = "missing"
+	stateRegistered    = "registered"
+	stateNotRegistered = "not registered"
+)
+
+// DiffContextState compares the operator sets, operator registrations and allocations declared in the context with
+// the state of the EigenLayer contracts
+func (cc *ContractCaller) DiffContextState(ctx context.Context, envCtx ChainContextConfig) ([]StateDiff, error) {
+	allocationManager, err := cc.registry.GetAllocationManager(cc.allocationManagerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AllocationManager: %w", err)
+	}
+	delegationManager, err := cc.registry.GetDelegationManager(cc.delegationManagerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DelegationManager: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	avs := common.HexToAddress(envCtx.Avs.Address)
+	var diffs []StateDiff
+
+	for _, opSet := range envCtx.OperatorSets {
+		operatorSet := allocationmanager.OperatorSet{Avs: avs, Id: uint32(opSet.OperatorSetID)}
+		exists, err := allocationManager.IsOperatorSet(opts, operatorSet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check operator set %d: %w", opSet.OperatorSetID, err)
+		}
+		diffs = append(diffs, StateDiff{Item: fmt.Sprintf("operator set %d", opSet.OperatorSetID), Want: stateExists, Have: existence(exists)})
+		if !exists {
+			continue
+		}
+
+		onChain, err := allocationManager.GetStrategiesInOperatorSet(opts, operatorSet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get strategies of operator set %d: %w", opSet.OperatorSetID, err)
+		}
+		want := make([]common.Address, len(opSet.Strategies))
+		for i, strategy := range opSet.Strategies {
+			want[i] = common.HexToAddress(strategy.StrategyAddress)
+		}
+		diffs = append(diffs, StateDiff{Item: fmt.Sprintf("operator set %d strategies", opSet.OperatorSetID), Want: addressList(want), Have: addressList(onChain)})
+	}
+
+	seen := map[common.Address]bool{}
+	for _, reg := range envCtx.OperatorRegistrations {
+		operator := common.HexToAddress(reg.Address)
+		if !seen[operator] {
+			seen[operator] = true
+			isOperator, err := delegationManager.IsOperator(opts, operator)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check operator %s: %w", operator.Hex(), err)
+			}
+			diffs = append(diffs, StateDiff{Item: fmt.Sprintf("operator %s with EigenLayer", operator.Hex()), Want: stateRegistered, Have: registration(isOperator)})
+		}
+
+		operatorSet := allocationmanager.OperatorSet{Avs: avs, Id: uint32(reg.OperatorSetID)}
+		member, err := allocationManager.IsMemberOfOperatorSet(opts, operator, operatorSet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check registration of %s to operator set %d: %w", operator.Hex(), reg.OperatorSetID, err)
+		}
+		diffs = append(diffs, StateDiff{Item: fmt.Sprintf("operator %s in operator set %d", operator.Hex(), reg.OperatorSetID), Want: stateRegistered, Have: registration(member)})
+	}
+
+	for _, op := range envCtx.Operators {
+		operator := common.HexToAddress(op.Address)
+		for _, allocation := range op.Allocations {
+			strategy := common.HexToAddress(allocation.StrategyAddress)
+			for _, opSetAllocation := range allocation.OperatorSetAllocations {
+				id, err := strconv.ParseUint(opSetAllocation.OperatorSet, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse operator set ID '%s': %w", opSetAllocation.OperatorSet, err)
+				}
+				current, err := allocationManager.GetAllocation(opts, operator, allocationmanager.OperatorSet{Avs: avs, Id: uint32(id)}, strategy)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get allocation of %s to operator set %d: %w", operator.Hex(), id, err)
+				}
+				have := strconv.FormatUint(current.CurrentMagnitude, 10)
+				if current.PendingDiff != nil && current.PendingDiff.Sign() != 0 {
+					have = fmt.Sprintf("%s (pending %+d at block %d)", have, current.PendingDiff, current.EffectBlock)
+				}
+				diffs = append(diffs, StateDiff{
+					Item: fmt.Sprintf("allocation of %s to operator set %d (%s)", operator.Hex(), id, strategy.Hex()),
+					Want: opSetAllocation.AllocationInWads,
+					Have: have,
+				})
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+func existence(exists bool) string {
+	if exists {
+		return stateExists
+	}
+	return stateMissing
+}
+
+func registration(registered bool) string {
+	if registered {
+		return stateRegistered
+	}
+	return stateNotRegistered
+}
+
+// addressList formats addresses as a sorted list, so lists holding the same addresses compare equal
+func addressList(addresses []common.Address) string {
+	hexes := make([]string, len(addresses))
+	for i, address := range addresses {
+		hexes[i] = address.Hex()
+	}
+	sort.Strings(hexes)
+	return "[" + strings.Join(hexes, ", ") + "]"
+}
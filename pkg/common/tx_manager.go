@@ -0,0 +1,470 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/iface"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Defaults of TxPolicy when the context sets no transactions section
+const (
+	DefaultReceiptTimeout  = 3 * time.Minute
+	DefaultMaxReplacements = 3
+	// feeBumpPercent raises the fees of a replacement, nodes reject replacements bumped by less than 10%
+	feeBumpPercent = 20
+)
+
+// TxPolicy controls how TxManager prices transactions and how long they may stay pending before being replaced
+type TxPolicy struct {
+	// MaxFeePerGas caps the fee per gas, nil leaves the node's suggestion uncapped
+	MaxFeePerGas *big.Int
+	// PriorityFeePerGas is the tip per gas, nil uses the node's suggestion
+	PriorityFeePerGas *big.Int
+	// GasMultiplier scales the estimated gas limit, values up to 1 leave it unchanged
+	GasMultiplier float64
+	// ReceiptTimeout is how long to wait for a receipt before replacing the transaction with higher fees, 0 waits forever
+	ReceiptTimeout time.Duration
+	// MaxReplacements bounds how often a stuck transaction is replaced before giving up
+	MaxReplacements int
+}
+
+// DefaultTxPolicy keeps the node's fee suggestions and replaces transactions pending for longer than DefaultReceiptTimeout
+func DefaultTxPolicy() TxPolicy {
+	return TxPolicy{
+		GasMultiplier:   1,
+		ReceiptTimeout:  DefaultReceiptTimeout,
+		MaxReplacements: DefaultMaxReplacements,
+	}
+}
+
+// TxBackend is the chain access TxManager needs to assign nonces, send transactions, wait for receipts and replay
+// reverted transactions
+type TxBackend interface {
+	bind.DeployBackend
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// TxRequest is a transaction for TxManager to submit
+type TxRequest struct {
+	Description string
+	// Opts sign the transaction, their nonce is assigned by the manager. Without Opts, Send builds its own
+	// options and From and Signer name the signer so its submissions are ordered and can be replaced.
+	Opts   *bind.TransactOpts
+	From   common.Address
+	Signer bind.SignerFn
+	// Send builds and signs the transaction using opts, which have NoSend set. The manager sends it.
+	Send func(opts *bind.TransactOpts) (*types.Transaction, error)
+}
+
+// TxResult is the outcome of a TxRequest
+type TxResult struct {
+	Description string
+	Tx          *types.Transaction
+	Receipt     *types.Receipt
+	Err         error
+}
+
+// TxManager submits transactions for any number of signers. Nonces are tracked per signer so several of a signer's
+// transactions can be pending at once, and batches of independent transactions are mined in parallel.
+type TxManager struct {
+	backend TxBackend
+	logger  iface.Logger
+	policy  TxPolicy
+	reverts *RevertDecoder
+
+	mu      sync.Mutex
+	signers map[common.Address]*signerNonce
+}
+
+// signerNonce serialises a signer's submissions and holds the nonce its next transaction uses
+type signerNonce struct {
+	mu sync.Mutex
+	// next is nil until read from the node, and reset after a failed submission
+	next *uint64
+}
+
+func NewTxManager(backend TxBackend, logger iface.Logger) *TxManager {
+	return &TxManager{
+		backend: backend,
+		logger:  logger,
+		policy:  DefaultTxPolicy(),
+		reverts: NewRevertDecoder(),
+		signers: map[common.Address]*signerNonce{},
+	}
+}
+
+// SetPolicy replaces the pricing and replacement policy of subsequent transactions
+func (m *TxManager) SetPolicy(policy TxPolicy) {
+	m.policy = policy
+}
+
+// SetRevertDecoder replaces the decoder used to explain reverted transactions
+func (m *TxManager) SetRevertDecoder(reverts *RevertDecoder) {
+	m.reverts = reverts
+}
+
+// ConfigureFromContext applies the context's transactions policy and decodes the errors of its deployed L1 contracts
+func (m *TxManager) ConfigureFromContext(envCtx ChainContextConfig) {
+	m.SetPolicy(envCtx.Transactions.TxPolicy())
+	for _, contract := range envCtx.DeployedL1Contracts {
+		if contract.Abi == "" {
+			continue
+		}
+		if err := m.reverts.AddArtifact(contract.Name, common.HexToAddress(contract.Address), contract.Abi); err != nil {
+			m.logger.Debug("Errors of %s will not be decoded: %v", contract.Name, err)
+		}
+	}
+}
+
+func (m *TxManager) signer(addr common.Address) *signerNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.signers[addr]
+	if !ok {
+		s = &signerNonce{}
+		m.signers[addr] = s
+	}
+	return s
+}
+
+// Submit sends the transaction with the signer's next nonce without waiting for it to be mined
+func (m *TxManager) Submit(ctx context.Context, req TxRequest) (*types.Transaction, error) {
+	from, signerFn := req.From, req.Signer
+	var opts *bind.TransactOpts
+	if req.Opts != nil {
+		from, signerFn = req.Opts.From, req.Opts.Signer
+		// Copy so concurrent requests sharing options don't race on the nonce
+		copied := *req.Opts
+		opts = &copied
+	}
+
+	s := m.signer(from)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if opts != nil {
+		if s.next == nil {
+			nonce, err := m.backend.PendingNonceAt(ctx, from)
+			if err != nil {
+				return nil, fmt.Errorf("%s nonce: %w", req.Description, err)
+			}
+			s.next = &nonce
+		}
+		opts.Nonce = new(big.Int).SetUint64(*s.next)
+		opts.Context = ctx
+		opts.NoSend = true
+	}
+
+	tx, err := req.Send(opts)
+	if err == nil && tx == nil {
+		err = errors.New("no transaction returned")
+	}
+	if err == nil {
+		tx, err = m.applyPolicy(tx, from, signerFn)
+	}
+	if err == nil {
+		err = m.send(ctx, tx)
+	}
+	if err != nil {
+		// The node may not have seen the nonce, so read it again for the next submission
+		s.next = nil
+		return nil, fmt.Errorf("%s execution: %w", req.Description, m.reverts.DecorateError(common.Address{}, err))
+	}
+
+	next := tx.Nonce() + 1
+	s.next = &next
+	return tx, nil
+}
+
+// send broadcasts tx, tolerating transactions the Send func already broadcast itself
+func (m *TxManager) send(ctx context.Context, tx *types.Transaction) error {
+	if err := m.backend.SendTransaction(ctx, tx); err != nil && !strings.Contains(err.Error(), "already known") {
+		return err
+	}
+	return nil
+}
+
+// applyPolicy re-prices tx with the policy's fees and gas multiplier, re-signing it if anything changed
+func (m *TxManager) applyPolicy(tx *types.Transaction, from common.Address, signerFn bind.SignerFn) (*types.Transaction, error) {
+	gas := tx.Gas()
+	if m.policy.GasMultiplier > 1 {
+		gas = uint64(float64(gas) * m.policy.GasMultiplier)
+	}
+
+	tip, feeCap := new(big.Int).Set(tx.GasTipCap()), new(big.Int).Set(tx.GasFeeCap())
+	if tx.Type() == types.DynamicFeeTxType && m.policy.PriorityFeePerGas != nil {
+		// bind sets the fee cap to the tip plus twice the base fee, keep the base fee headroom
+		feeCap.Add(feeCap.Sub(feeCap, tip), m.policy.PriorityFeePerGas)
+		tip.Set(m.policy.PriorityFeePerGas)
+	}
+	capFees(tip, feeCap, m.policy.MaxFeePerGas)
+
+	if gas == tx.Gas() && tip.Cmp(tx.GasTipCap()) == 0 && feeCap.Cmp(tx.GasFeeCap()) == 0 {
+		return tx, nil
+	}
+	return resignTx(tx, from, signerFn, gas, tip, feeCap)
+}
+
+// Wait blocks until the transaction or one of its replacements is mined and fails if it reverted. A transaction
+// pending for longer than the policy's receipt timeout is replaced by the same transaction with bumped fees.
+func (m *TxManager) Wait(ctx context.Context, req TxRequest, tx *types.Transaction) (*types.Transaction, *types.Receipt, error) {
+	from, signerFn := req.From, req.Signer
+	if req.Opts != nil {
+		from, signerFn = req.Opts.From, req.Opts.Signer
+	}
+
+	sent := []*types.Transaction{tx}
+	for replacements := 0; ; replacements++ {
+		waitCtx, cancel := ctx, context.CancelFunc(func() {})
+		if m.policy.ReceiptTimeout > 0 {
+			waitCtx, cancel = context.WithTimeout(ctx, m.policy.ReceiptTimeout)
+		}
+		receipt, err := bind.WaitMined(waitCtx, m.backend, tx)
+		cancel()
+
+		// A transaction replaced earlier may have been mined instead
+		if err != nil && ctx.Err() == nil {
+			for _, prev := range sent {
+				if prevReceipt, prevErr := m.backend.TransactionReceipt(ctx, prev.Hash()); prevErr == nil {
+					tx, receipt, err = prev, prevReceipt, nil
+					break
+				}
+			}
+		}
+
+		if err == nil {
+			if receipt.Status == types.ReceiptStatusFailed {
+				return tx, receipt, m.revertError(ctx, req.Description, from, tx, receipt)
+			}
+			return tx, receipt, nil
+		}
+		if ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+			return tx, nil, fmt.Errorf("waiting for %s transaction (hash: %s): %w", req.Description, tx.Hash().Hex(), err)
+		}
+		if replacements >= m.policy.MaxReplacements || signerFn == nil {
+			return tx, nil, fmt.Errorf("%s transaction (hash: %s) not mined after %s and %d fee bumps", req.Description, tx.Hash().Hex(), m.policy.ReceiptTimeout*time.Duration(replacements+1), replacements)
+		}
+
+		replacement, err := m.bumpFees(tx, from, signerFn)
+		if err != nil {
+			return tx, nil, fmt.Errorf("replacing %s transaction (hash: %s): %w", req.Description, tx.Hash().Hex(), err)
+		}
+		if err := m.send(ctx, replacement); err != nil {
+			// One of the sent transactions was mined since the timeout, the next wait finds its receipt
+			if strings.Contains(err.Error(), "nonce too low") {
+				continue
+			}
+			return tx, nil, fmt.Errorf("replacing %s transaction (hash: %s): %w", req.Description, tx.Hash().Hex(), err)
+		}
+		m.logger.Warn("%s transaction %s pending for %s, replaced with %s at a %d%% higher fee", req.Description, tx.Hash().Hex(), m.policy.ReceiptTimeout, replacement.Hash().Hex(), feeBumpPercent)
+		tx = replacement
+		sent = append(sent, tx)
+	}
+}
+
+// revertError explains a reverted transaction by replaying it on the state it was mined on, and logs its call trace
+// in verbose mode
+func (m *TxManager) revertError(ctx context.Context, description string, from common.Address, tx *types.Transaction, receipt *types.Receipt) error {
+	if VerboseFromContext(ctx) {
+		m.logCallTrace(ctx, description, tx)
+	}
+
+	var to common.Address
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	call := ethereum.CallMsg{From: from, To: tx.To(), Gas: tx.Gas(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList()}
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	if _, err := m.backend.CallContract(ctx, call, parent); err != nil {
+		if reason := m.reverts.Decode(to, RevertData(err)); reason != "" {
+			return fmt.Errorf("%s transaction (hash: %s) reverted: %s", description, tx.Hash().Hex(), reason)
+		}
+	}
+	return fmt.Errorf("%s transaction (hash: %s) reverted", description, tx.Hash().Hex())
+}
+
+// logCallTrace logs the decoded call trace of a transaction, if the node serves debug_traceTransaction
+func (m *TxManager) logCallTrace(ctx context.Context, description string, tx *types.Transaction) {
+	backend, ok := m.backend.(interface{ Client() *rpc.Client })
+	if !ok {
+		return
+	}
+	trace, err := m.reverts.TraceTransaction(ctx, backend.Client(), tx.Hash())
+	if err != nil {
+		m.logger.Debug("No call trace of %s transaction %s: %v", description, tx.Hash().Hex(), err)
+		return
+	}
+	m.logger.Debug("Call trace of %s transaction %s:\n%s", description, tx.Hash().Hex(), trace)
+}
+
+// bumpFees returns tx re-signed with fees raised by feeBumpPercent, within the policy's max fee
+func (m *TxManager) bumpFees(tx *types.Transaction, from common.Address, signerFn bind.SignerFn) (*types.Transaction, error) {
+	tip, feeCap := bumpFee(tx.GasTipCap()), bumpFee(tx.GasFeeCap())
+	capFees(tip, feeCap, m.policy.MaxFeePerGas)
+	if feeCap.Cmp(bumpFee(tx.GasFeeCap())) < 0 {
+		return nil, fmt.Errorf("fees already at the max fee per gas of %s wei", m.policy.MaxFeePerGas)
+	}
+	return resignTx(tx, from, signerFn, tx.Gas(), tip, feeCap)
+}
+
+// bumpFee raises fee by feeBumpPercent, by at least 1 wei
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+feeBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
+
+// capFees lowers feeCap to maxFee, and tip to feeCap, in place
+func capFees(tip, feeCap, maxFee *big.Int) {
+	if maxFee != nil && feeCap.Cmp(maxFee) > 0 {
+		feeCap.Set(maxFee)
+	}
+	if tip.Cmp(feeCap) > 0 {
+		tip.Set(feeCap)
+	}
+}
+
+// resignTx signs a copy of tx with the given gas limit and fees
+func resignTx(tx *types.Transaction, from common.Address, signerFn bind.SignerFn, gas uint64, tip, feeCap *big.Int) (*types.Transaction, error) {
+	if signerFn == nil {
+		return nil, errors.New("no signer to re-sign the transaction")
+	}
+
+	var inner types.TxData
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tip,
+			GasFeeCap:  feeCap,
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	case types.LegacyTxType:
+		// Legacy transactions pay a single gas price, which both fees equal
+		inner = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: feeCap,
+			Gas:      gas,
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	default:
+		return nil, fmt.Errorf("cannot re-price transactions of type %d", tx.Type())
+	}
+	return signerFn(from, types.NewTx(inner))
+}
+
+// Send submits the transaction and waits for it, or a replacement, to be mined. With a TxPlan in ctx the transaction
+// is only simulated and recorded in the plan.
+func (m *TxManager) Send(ctx context.Context, req TxRequest) TxResult {
+	if plan := TxPlanFromContext(ctx); plan != nil {
+		return m.simulate(ctx, plan, req)
+	}
+	result := TxResult{Description: req.Description}
+	result.Tx, result.Err = m.Submit(ctx, req)
+	if result.Err != nil {
+		return result
+	}
+	result.Tx, result.Receipt, result.Err = m.Wait(ctx, req, result.Tx)
+	return result
+}
+
+// simulate builds the transaction, which has the node estimate its gas, and records it in the plan without sending
+// it. Failed simulations are recorded rather than returned, as they may depend on earlier planned transactions.
+func (m *TxManager) simulate(ctx context.Context, plan *TxPlan, req TxRequest) TxResult {
+	planned := PlannedTx{Description: req.Description, From: req.From}
+	var opts *bind.TransactOpts
+	if req.Opts != nil {
+		planned.From = req.Opts.From
+		copied := *req.Opts
+		copied.Context = ctx
+		copied.NoSend = true
+		opts = &copied
+
+		// Planned transactions aren't sent, so each is simulated with the signer's next nonce on chain
+		nonce, err := m.backend.PendingNonceAt(ctx, planned.From)
+		if err != nil {
+			planned.Err = fmt.Errorf("nonce: %w", err)
+			plan.Add(planned)
+			return TxResult{Description: req.Description}
+		}
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+	}
+
+	tx, err := req.Send(opts)
+	if err == nil && tx == nil {
+		err = errors.New("no transaction returned")
+	}
+	if err != nil {
+		planned.Err = m.reverts.DecorateError(common.Address{}, err)
+	} else {
+		var to common.Address
+		if tx.To() != nil {
+			to = *tx.To()
+		}
+		planned.To, planned.Gas, planned.Call = tx.To(), tx.Gas(), m.reverts.DecodeCall(to, tx.Data())
+	}
+
+	plan.Add(planned)
+	m.logger.Info("[dry-run] %s", planned)
+	return TxResult{Description: req.Description, Tx: tx}
+}
+
+// SendAll submits the requests concurrently and waits for their receipts in parallel, returning the results in
+// request order. The requests must not depend on each other, as a signer's transactions may be mined in any order.
+func (m *TxManager) SendAll(ctx context.Context, reqs []TxRequest) []TxResult {
+	results := make([]TxResult, len(reqs))
+	// Simulate one by one so the plan lists the transactions in request order
+	if TxPlanFromContext(ctx) != nil {
+		for i, req := range reqs {
+			results[i] = m.Send(ctx, req)
+		}
+		return results
+	}
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func(i int, req TxRequest) {
+			defer wg.Done()
+			results[i] = m.Send(ctx, req)
+			if results[i].Receipt != nil {
+				m.logger.Debug("%s mined in block %d (hash: %s)", req.Description, results[i].Receipt.BlockNumber, results[i].Tx.Hash().Hex())
+			}
+		}(i, req)
+	}
+	wg.Wait()
+	return results
+}
+
+// TxResultsError joins the errors of the failed results, nil when every transaction succeeded
+func TxResultsError(results []TxResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
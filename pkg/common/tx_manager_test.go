@@ -0,0 +1,259 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
+	"github.com/Layr-Labs/devkit-cli/pkg/testutils/testchain"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newChainTxManager returns a manager over an anvil chain started with args, and a funded signer. The chain mines
+// every transaction as it is sent unless started with --no-mining.
+func newChainTxManager(t *testing.T, args ...string) (*TxManager, *testchain.Chain, *bind.TransactOpts) {
+	chain := testchain.Start(t, args...)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chain.SetBalance(t, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(params.Ether))
+
+	chainID, err := chain.Client.ChainID(context.Background())
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+	return NewTxManager(chain.Client, logger.NewNoopLogger()), chain, opts
+}
+
+// transferRequest signs a transfer of 1 wei to recipient, paying a 1 gwei tip and up to 10 gwei per gas
+func transferRequest(client *ethclient.Client, opts *bind.TransactOpts, recipient common.Address) TxRequest {
+	return TxRequest{
+		Description: "transfer to " + recipient.Hex(),
+		Opts:        opts,
+		Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			chainID, err := client.ChainID(opts.Context)
+			if err != nil {
+				return nil, err
+			}
+			tx := types.NewTx(&types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     opts.Nonce.Uint64(),
+				To:        &recipient,
+				Value:     big.NewInt(1),
+				Gas:       params.TxGas,
+				GasTipCap: big.NewInt(params.GWei),
+				GasFeeCap: big.NewInt(params.GWei * 10),
+			})
+			return opts.Signer(opts.From, tx)
+		},
+	}
+}
+
+func TestTxManagerSendAllAssignsNonces(t *testing.T) {
+	manager, chain, opts := newChainTxManager(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	recipients := []common.Address{common.HexToAddress("0x1001"), common.HexToAddress("0x1002"), common.HexToAddress("0x1003"), common.HexToAddress("0x1004")}
+	reqs := make([]TxRequest, len(recipients))
+	for i, recipient := range recipients {
+		reqs[i] = transferRequest(chain.Client, opts, recipient)
+	}
+
+	results := manager.SendAll(ctx, reqs)
+	require.NoError(t, TxResultsError(results))
+
+	nonces := map[uint64]bool{}
+	for i, result := range results {
+		assert.Equal(t, reqs[i].Description, result.Description)
+		assert.Equal(t, recipients[i], *result.Tx.To())
+		assert.Equal(t, types.ReceiptStatusSuccessful, result.Receipt.Status)
+		nonces[result.Tx.Nonce()] = true
+	}
+	assert.Equal(t, map[uint64]bool{0: true, 1: true, 2: true, 3: true}, nonces)
+}
+
+func TestTxManagerRecoversNonceAfterFailedSend(t *testing.T) {
+	manager, chain, opts := newChainTxManager(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result := manager.Send(ctx, transferRequest(chain.Client, opts, common.HexToAddress("0x1001")))
+	require.NoError(t, result.Err)
+	assert.Equal(t, uint64(0), result.Tx.Nonce())
+
+	failing := TxRequest{
+		Description: "failing",
+		Opts:        opts,
+		Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return nil, errors.New("rejected")
+		},
+	}
+	results := manager.SendAll(ctx, []TxRequest{failing})
+	err := TxResultsError(results)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failing execution: rejected")
+
+	// The nonce the failed request was given is reused
+	result = manager.Send(ctx, transferRequest(chain.Client, opts, common.HexToAddress("0x1002")))
+	require.NoError(t, result.Err)
+	assert.Equal(t, uint64(1), result.Tx.Nonce())
+}
+
+func TestTxManagerReplacesStuckTransaction(t *testing.T) {
+	manager, chain, opts := newChainTxManager(t, "--no-mining")
+	manager.SetPolicy(TxPolicy{GasMultiplier: 1, ReceiptTimeout: 300 * time.Millisecond, MaxReplacements: 10})
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Nothing is mined until the transaction has been replaced a few times
+	go func() {
+		time.Sleep(time.Second)
+		_ = chain.Mine(context.Background())
+	}()
+	result := manager.Send(ctx, transferRequest(chain.Client, opts, common.HexToAddress("0x1001")))
+	require.NoError(t, result.Err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, result.Receipt.Status)
+	assert.Equal(t, uint64(0), result.Tx.Nonce())
+	assert.Equal(t, 1, result.Tx.GasFeeCap().Cmp(big.NewInt(params.GWei*10)))
+	assert.Equal(t, 1, result.Tx.GasTipCap().Cmp(big.NewInt(params.GWei)))
+}
+
+func TestTxManagerGivesUpAfterMaxReplacements(t *testing.T) {
+	manager, chain, opts := newChainTxManager(t, "--no-mining")
+	manager.SetPolicy(TxPolicy{GasMultiplier: 1, ReceiptTimeout: 200 * time.Millisecond, MaxReplacements: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result := manager.Send(ctx, transferRequest(chain.Client, opts, common.HexToAddress("0x1001")))
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "not mined after 400ms and 1 fee bumps")
+}
+
+func TestTxManagerAppliesPolicy(t *testing.T) {
+	manager, chain, opts := newChainTxManager(t, "--no-mining")
+	ctx := context.Background()
+	opts.Context, opts.Nonce = ctx, big.NewInt(0)
+	tx, err := transferRequest(chain.Client, opts, common.HexToAddress("0x1001")).Send(opts)
+	require.NoError(t, err)
+
+	policy := (&TransactionsConfig{MaxFeeGwei: 5, PriorityFeeGwei: 2, GasMultiplier: 1.5}).TxPolicy()
+	assert.Equal(t, DefaultReceiptTimeout, policy.ReceiptTimeout)
+	manager.SetPolicy(policy)
+
+	priced, err := manager.applyPolicy(tx, opts.From, opts.Signer)
+	require.NoError(t, err)
+	assert.Equal(t, params.TxGas*3/2, priced.Gas())
+	assert.Equal(t, big.NewInt(params.GWei*2), priced.GasTipCap())
+	// The 9 gwei base fee headroom plus the 2 gwei tip is capped at 5 gwei
+	assert.Equal(t, big.NewInt(params.GWei*5), priced.GasFeeCap())
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), priced)
+	require.NoError(t, err)
+	assert.Equal(t, opts.From, sender)
+
+	// The default policy leaves the transaction untouched
+	manager.SetPolicy(DefaultTxPolicy())
+	unchanged, err := manager.applyPolicy(tx, opts.From, opts.Signer)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), unchanged.Hash())
+}
+
+func TestTxManagerDecodesRevert(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"error","name":"Stuck","inputs":[{"name":"code","type":"uint256"}]}]`))
+	require.NoError(t, err)
+
+	// The contract reverts every call with Stuck(7)
+	selector := parsed.Errors["Stuck"].ID.Bytes()[:4]
+	code := append([]byte{0x63}, selector...)
+	code = append(code, 0x60, 0xe0, 0x1b, 0x60, 0x00, 0x52, 0x60, 0x07, 0x60, 0x04, 0x52, 0x60, 0x24, 0x60, 0x00, 0xfd)
+	stuck := common.HexToAddress("0x2001")
+
+	manager, chain, opts := newChainTxManager(t)
+	chain.SetCode(t, stuck, code)
+	manager.reverts.AddABI("Tester", stuck, &parsed)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	call := func(estimate bool) TxRequest {
+		return TxRequest{
+			Description: "call",
+			Opts:        opts,
+			Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				if estimate {
+					if _, err := chain.Client.EstimateGas(opts.Context, ethereum.CallMsg{From: opts.From, To: &stuck}); err != nil {
+						return nil, err
+					}
+				}
+				chainID, err := chain.Client.ChainID(opts.Context)
+				if err != nil {
+					return nil, err
+				}
+				return opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+					ChainID:   chainID,
+					Nonce:     opts.Nonce.Uint64(),
+					To:        &stuck,
+					Gas:       100000,
+					GasTipCap: big.NewInt(params.GWei),
+					GasFeeCap: big.NewInt(params.GWei * 10),
+				}))
+			},
+		}
+	}
+
+	// Reverts while estimating gas carry the revert data
+	result := manager.Send(ctx, call(true))
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "call execution: execution reverted: Tester.Stuck(7)")
+
+	// Mined reverts are replayed to recover it
+	result = manager.Send(ctx, call(false))
+	require.Error(t, result.Err)
+	assert.Equal(t, types.ReceiptStatusFailed, result.Receipt.Status)
+	assert.Contains(t, result.Err.Error(), "reverted: Tester.Stuck(7)")
+}
+
+func TestTxManagerDryRun(t *testing.T) {
+	manager, chain, opts := newChainTxManager(t)
+	plan := NewTxPlan()
+	ctx, cancel := context.WithTimeout(WithTxPlan(context.Background(), plan), 30*time.Second)
+	defer cancel()
+
+	failing := TxRequest{
+		Description: "failing",
+		Opts:        opts,
+		Send: func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return nil, errors.New("execution reverted")
+		},
+	}
+	results := manager.SendAll(ctx, []TxRequest{transferRequest(chain.Client, opts, common.HexToAddress("0x1001")), failing})
+	require.NoError(t, TxResultsError(results))
+	assert.Nil(t, results[0].Receipt)
+
+	txs := plan.Transactions()
+	require.Len(t, txs, 2)
+	assert.Equal(t, opts.From, txs[0].From)
+	assert.Equal(t, common.HexToAddress("0x1001"), *txs[0].To)
+	assert.Equal(t, "transfer", txs[0].Call)
+	assert.Equal(t, params.TxGas, txs[0].Gas)
+	assert.NoError(t, txs[0].Err)
+	assert.EqualError(t, txs[1].Err, "execution reverted")
+	assert.Contains(t, txs[1].String(), "failing from "+opts.From.Hex()+": simulation failed: execution reverted")
+
+	// Nothing was sent
+	nonce, err := chain.Client.PendingNonceAt(context.Background(), opts.From)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), nonce)
+}
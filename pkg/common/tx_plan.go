@@ -0,0 +1,72 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// txPlanContextKey is used to store the dry run plan in the context
+type txPlanContextKey struct{}
+
+// TxPlan collects the transactions of a dry run. A TxManager given a context carrying a plan simulates and records
+// transactions instead of sending them.
+type TxPlan struct {
+	mu  sync.Mutex
+	txs []PlannedTx
+}
+
+// PlannedTx is a transaction a dry run would send
+type PlannedTx struct {
+	Description string
+	From        common.Address
+	To          *common.Address
+	// Call is the decoded call, e.g. `AllocationManager.createOperatorSets(0x..., [...])`
+	Call string
+	// Gas is the gas the node estimated for the transaction
+	Gas uint64
+	// Err is why the simulation failed, e.g. a revert caused by state an earlier planned transaction would create
+	Err error
+}
+
+func NewTxPlan() *TxPlan {
+	return &TxPlan{}
+}
+
+// Add records a planned transaction
+func (p *TxPlan) Add(tx PlannedTx) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.txs = append(p.txs, tx)
+}
+
+// Transactions returns the planned transactions in the order they were planned
+func (p *TxPlan) Transactions() []PlannedTx {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedTx(nil), p.txs...)
+}
+
+func (tx PlannedTx) String() string {
+	if tx.Err != nil {
+		return fmt.Sprintf("%s from %s: simulation failed: %v", tx.Description, tx.From.Hex(), tx.Err)
+	}
+	to := "contract creation"
+	if tx.To != nil {
+		to = tx.To.Hex()
+	}
+	return fmt.Sprintf("%s from %s to %s: %s (estimated gas: %d)", tx.Description, tx.From.Hex(), to, tx.Call, tx.Gas)
+}
+
+// WithTxPlan stores a dry run plan in the context, so transactions are recorded in it instead of being sent
+func WithTxPlan(ctx context.Context, plan *TxPlan) context.Context {
+	return context.WithValue(ctx, txPlanContextKey{}, plan)
+}
+
+// TxPlanFromContext returns the dry run plan of the context, nil when transactions are sent
+func TxPlanFromContext(ctx context.Context) *TxPlan {
+	plan, _ := ctx.Value(txPlanContextKey{}).(*TxPlan)
+	return plan
+}
@@ -29,6 +29,9 @@ type loggerContextKey struct{}
 // progressTrackerContextKey is used to store the progress tracker in the context
 type progressTrackerContextKey struct{}
 
+// verboseContextKey is used to store the --verbose flag in the context
+type verboseContextKey struct{}
+
 // RexExp to match semver strings
 var semverRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
 
@@ -80,6 +83,17 @@ func WithLogger(ctx context.Context, logger iface.Logger) context.Context {
 	return context.WithValue(ctx, loggerContextKey{}, logger)
 }
 
+// WithVerbose stores whether --verbose is set in the context
+func WithVerbose(ctx context.Context, verbose bool) context.Context {
+	return context.WithValue(ctx, verboseContextKey{}, verbose)
+}
+
+// VerboseFromContext reports whether --verbose is set, false if the context doesn't say
+func VerboseFromContext(ctx context.Context) bool {
+	verbose, _ := ctx.Value(verboseContextKey{}).(bool)
+	return verbose
+}
+
 // WithProgressTracker stores the progress tracker in the context
 func WithProgressTracker(ctx context.Context, tracker iface.ProgressTracker) context.Context {
 	return context.WithValue(ctx, progressTrackerContextKey{}, tracker)
@@ -149,20 +163,21 @@ func ParseETHAmount(amountStr string) (*big.Int, error) {
 	return weiAmount, nil
 }
 
-// ImpersonateAccount enables impersonation of an account on Anvil
+// ImpersonateAccount enables impersonation of an account on a devnet chain
+// The hardhat_ method name is served by both anvil and hardhat nodes
 func ImpersonateAccount(client *rpc.Client, address common.Address) error {
 	var result interface{}
-	err := client.Call(&result, "anvil_impersonateAccount", address.Hex())
+	err := client.Call(&result, "hardhat_impersonateAccount", address.Hex())
 	if err != nil {
 		return fmt.Errorf("failed to impersonate account %s: %w", address.Hex(), err)
 	}
 	return nil
 }
 
-// StopImpersonatingAccount disables impersonation of an account on Anvil
+// StopImpersonatingAccount disables impersonation of an account on a devnet chain
 func StopImpersonatingAccount(client *rpc.Client, address common.Address) error {
 	var result interface{}
-	err := client.Call(&result, "anvil_stopImpersonatingAccount", address.Hex())
+	err := client.Call(&result, "hardhat_stopImpersonatingAccount", address.Hex())
 	if err != nil {
 		return fmt.Errorf("failed to stop impersonating account %s: %w", address.Hex(), err)
 	}
@@ -0,0 +1,78 @@
+// Package testchain runs local anvil chains for integration tests. It only depends on go-ethereum, so the tests of
+// every package, including pkg/common, can use it. Tests starting a chain are skipped when anvil is not installed.
+package testchain
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"os/exec"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// Chain is an anvil node stopped when the test ends
+type Chain struct {
+	URL    string
+	RPC    *rpc.Client
+	Client *ethclient.Client
+}
+
+// Start launches anvil on a free port with 100M gas blocks, skipping the test when anvil is not installed or the
+// tests run with -short. args are passed through to anvil, e.g. "--no-mining" to only mine on Mine.
+func Start(t *testing.T, args ...string) *Chain {
+	t.Helper()
+	anvil, err := exec.LookPath("anvil")
+	if err != nil {
+		t.Skip("anvil not installed, skipping integration test")
+	}
+	if testing.Short() {
+		t.Skip("skipping anvil integration test in short mode")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	args = append([]string{"--port", strconv.Itoa(port), "--gas-limit", "100000000", "--silent"}, args...)
+	cmd := exec.Command(anvil, args...)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	url := "http://127.0.0.1:" + strconv.Itoa(port)
+	client, err := rpc.Dial(url)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	require.Eventually(t, func() bool {
+		var chainID hexutil.Big
+		return client.CallContext(context.Background(), &chainID, "eth_chainId") == nil
+	}, 10*time.Second, 50*time.Millisecond, "anvil did not start on %s", url)
+
+	return &Chain{URL: url, RPC: client, Client: ethclient.NewClient(client)}
+}
+
+// SetBalance sets the balance of account to wei
+func (c *Chain) SetBalance(t *testing.T, account common.Address, wei *big.Int) {
+	require.NoError(t, c.RPC.CallContext(context.Background(), nil, "anvil_setBalance", account, (*hexutil.Big)(wei)))
+}
+
+// SetCode replaces the runtime code at account
+func (c *Chain) SetCode(t *testing.T, account common.Address, code []byte) {
+	require.NoError(t, c.RPC.CallContext(context.Background(), nil, "anvil_setCode", account, hexutil.Bytes(code)))
+}
+
+// Mine seals the pending transactions into a block
+func (c *Chain) Mine(ctx context.Context) error {
+	return c.RPC.CallContext(ctx, nil, "evm_mine")
+}
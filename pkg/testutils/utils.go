@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -13,6 +14,8 @@ import (
 	"github.com/Layr-Labs/devkit-cli/pkg/common"
 	"github.com/Layr-Labs/devkit-cli/pkg/common/logger"
 
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
 )
 
@@ -249,3 +252,15 @@ func CaptureOutput(fn func()) (stdout string, stderr string) {
 
 	return stdout, stderr
 }
+
+// ServeRPC serves each namespace's methods as a JSON-RPC endpoint until the test ends and returns its URL,
+// e.g. ServeRPC(t, map[string]any{"eth": fakeChain}) to stand in for a node
+func ServeRPC(t *testing.T, namespaces map[string]any) string {
+	server := rpc.NewServer()
+	for namespace, service := range namespaces {
+		require.NoError(t, server.RegisterName(namespace, service))
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer.URL
+}